@@ -42,7 +42,11 @@ func Run() {
 		return
 	}
 
-	useCase := usecase.New(repo)
+	useCase, err := usecase.New(repo, cfg)
+	if err != nil {
+		logger.Fatal("error creating usecase", zap.Error(err))
+		return
+	}
 
 	prHandler := handler.New(useCase)
 
@@ -50,17 +54,55 @@ func Run() {
 	teamGroup := server.Group("/team")
 	teamGroup.POST("/add", prHandler.CreateTeam)
 	teamGroup.GET("/get", prHandler.GetTeam)
+	teamGroup.POST("/updateSettings", prHandler.UpdateTeamSettings)
+	teamGroup.POST("/addMember", prHandler.AddTeamMember)
+	teamGroup.POST("/updateMember", prHandler.UpdateTeamMember)
+	teamGroup.POST("/removeMember", prHandler.RemoveTeamMember)
+	teamGroup.GET("/getAbsences", prHandler.GetTeamAbsences)
 
 	//Users
 	usersGroup := server.Group("/users")
 	usersGroup.POST("/setIsActive", prHandler.SetIsActive)
 	usersGroup.GET("/getReview", prHandler.GetReview)
+	usersGroup.POST("/moveTeam", prHandler.MoveUserTeam)
+	usersGroup.POST("/addAbsence", prHandler.AddAbsence)
+	usersGroup.GET("/getAbsences", prHandler.GetAbsences)
+	usersGroup.POST("/updateAbsence", prHandler.UpdateAbsence)
+	usersGroup.POST("/deleteAbsence", prHandler.DeleteAbsence)
+	usersGroup.POST("/setSeniority", prHandler.SetSeniority)
+	usersGroup.POST("/setMaxOpenReviews", prHandler.SetMaxOpenReviews)
+	usersGroup.POST("/addTags", prHandler.AddUserTags)
+	usersGroup.POST("/removeTags", prHandler.RemoveUserTags)
 
 	//Pull Request
 	prGroup := server.Group("/pullRequest")
 	prGroup.POST("/create", prHandler.PullRequestCreate)
+	prGroup.POST("/preview", prHandler.PreviewPullRequest)
+	prGroup.GET("/get", prHandler.GetPullRequest)
 	prGroup.POST("/merge", prHandler.MergePR)
+	prGroup.POST("/ready", prHandler.MarkReady)
+	prGroup.POST("/close", prHandler.ClosePR)
+	prGroup.POST("/reopen", prHandler.ReopenPR)
 	prGroup.POST("/reassign", prHandler.ReassignPrReviewer)
+	prGroup.POST("/addReviewer", prHandler.AddReviewer)
+	prGroup.POST("/removeReviewer", prHandler.RemoveReviewer)
+	prGroup.POST("/submitReview", prHandler.SubmitReview)
+
+	//Repositories
+	repositoryGroup := server.Group("/repository")
+	repositoryGroup.POST("/add", prHandler.SaveRepository)
+	repositoryGroup.GET("/get", prHandler.GetRepository)
+
+	//Code owners
+	codeOwnersGroup := server.Group("/codeOwners")
+	codeOwnersGroup.POST("/import", prHandler.ImportCodeOwners)
+	codeOwnersGroup.GET("/get", prHandler.GetCodeOwners)
+
+	//Admin
+	adminGroup := server.Group("/admin")
+	adminGroup.POST("/backfillReviewers", prHandler.BackfillReviewers)
+	adminGroup.GET("/mergeOverrides", prHandler.GetMergeOverrides)
+	adminGroup.POST("/setIsAdmin", prHandler.SetIsAdmin)
 
 	//Metrics
 	server.GET("/metrics", gin.WrapH(promhttp.Handler()))
@@ -19,6 +19,8 @@ type Config struct {
 	MaxRetries            int           `env:"MAX_RETRIES" env-default:"5"`
 	RetryDelay            time.Duration `env:"RETRY_DELAY" env-default:"3s"`
 	PackageWithMigrations string        `env:"PACKAGE_WITH_MIGRATIONS" env-default:"./migrations"`
+	AssignmentStrategy    string        `env:"ASSIGNMENT_STRATEGY" env-default:"random"`
+	AssignmentSeed        int64         `env:"ASSIGNMENT_SEED" env-default:"0"` // 0 - сид от текущего времени
 }
 
 // New - конструктор конфига
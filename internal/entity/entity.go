@@ -2,47 +2,479 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
 // User - структура пользователя
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	TeamName  string `json:"team_name"` // основная команда
+	IsActive  bool   `json:"is_active"`
+	Seniority string `json:"seniority,omitempty"`
+	IsAdmin   bool   `json:"is_admin"` // может мержить pr в обход политики
+}
+
+// UserActivity - изменение активности пользователя
+type UserActivity struct {
+	UserID          string `json:"user_id"`
+	IsActive        bool   `json:"is_active"`
+	ReassignReviews *bool  `json:"reassign_reviews,omitempty"` // nil - настройка команды reassign_on_deactivate
+}
+
+// UserActivityResult - результат изменения активности
+type UserActivityResult struct {
+	UserID        string             `json:"user_id"`
+	IsActive      bool               `json:"is_active"`
+	Reassigned    []ReassignedReview `json:"reassigned,omitempty"`
+	NotReassigned []FailedReassign   `json:"not_reassigned,omitempty"`
+	Backfilled    []BackfilledPR     `json:"backfilled,omitempty"` // pr, на которые пользователь добавлен после активации
+	Error         string             `json:"error,omitempty"`      // активность сохранена, но передача ревью или добор прерваны
+}
+
+// BackfillRequest - запрос на добор ревьюверов для команды
+type BackfillRequest struct {
 	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
 }
 
+// BackfillResult - результат добора ревьюверов на OPEN pr команды
+type BackfillResult struct {
+	TeamName     string           `json:"team_name"`
+	Backfilled   []BackfilledPR   `json:"backfilled"`
+	Understaffed []string         `json:"understaffed"` // pr, которым по-прежнему не хватает ревьюверов
+	Failed       []FailedBackfill `json:"failed"`       // pr, добор которых завершился ошибкой
+}
+
+// FailedBackfill - pr, на который не удалось добрать ревьюверов
+type FailedBackfill struct {
+	PullRequestID string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
+// BackfilledPR - pr, на который добавлены ревьюверы
+type BackfilledPR struct {
+	PullRequestID  string   `json:"pull_request_id"`
+	AddedReviewers []string `json:"added_reviewers"`
+}
+
+// ReassignedReview - ревью, переданное другому ревьюверу
+type ReassignedReview struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+// FailedReassign - ревью, которое не удалось передать
+type FailedReassign struct {
+	PullRequestID string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
+// Уровни пользователя
+const (
+	SeniorityJunior = "junior"
+	SeniorityMiddle = "middle"
+	SenioritySenior = "senior"
+)
+
 // TeamMember - участник команды
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	IsActive       bool     `json:"is_active"`
+	Seniority      string   `json:"seniority"`                  // junior / middle / senior, по умолчанию middle
+	Tags           []string `json:"tags,omitempty"`             // экспертиза: go, sql, frontend...
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"` // лимит OPEN pr на ревью, nil - лимит команды
+	IsAway         bool     `json:"is_away"`                    // сейчас идет период отсутствия
+}
+
+// TeamMemberAdd - запрос на добавление участника в команду
+type TeamMemberAdd struct {
+	TeamName string `json:"team_name"`
+	TeamMember
+}
+
+// TeamMemberUpdate - изменение участника команды, nil поля не меняются
+type TeamMemberUpdate struct {
+	TeamName       string   `json:"team_name"`
+	UserID         string   `json:"user_id"`
+	Username       *string  `json:"username"`
+	Seniority      *string  `json:"seniority"`
+	MaxOpenReviews *int     `json:"max_open_reviews"` // 0 снимает лимит
+	Tags           []string `json:"tags"`             // заменяет теги, пустой массив очищает их
+}
+
+// TeamMemberRemove - запрос на удаление участника из команды
+type TeamMemberRemove struct {
+	TeamName        string `json:"team_name"`
+	UserID          string `json:"user_id"`
+	ReassignReviews bool   `json:"reassign_reviews"` // передать открытые ревью, иначе их наличие - ошибка
+}
+
+// TeamMemberResult - команда после изменения состава
+type TeamMemberResult struct {
+	Team       *Team              `json:"team"`
+	Reassigned []ReassignedReview `json:"reassigned,omitempty"` // ревью удаленного участника, переданные другим
+	Backfilled []BackfilledPR     `json:"backfilled,omitempty"` // pr, на которые добавлен новый участник
+	Error      string             `json:"error,omitempty"`      // участник добавлен, но добор прерван
+}
+
+// UserMove - перевод пользователя в другую команду
+type UserMove struct {
+	UserID          string `json:"user_id"`
+	FromTeam        string `json:"from_team"`        // обязательна, если пользователь состоит в нескольких командах
+	TeamName        string `json:"team_name"`        // новая команда
+	ReassignReviews bool   `json:"reassign_reviews"` // передать открытые ревью pr старой команды ее оставшимся участникам
+}
+
+// UserMoveResult - результат перевода пользователя
+type UserMoveResult struct {
+	UserID        string             `json:"user_id"`
+	FromTeam      string             `json:"from_team,omitempty"` // пусто - пользователь был без команды
+	ToTeam        string             `json:"to_team"`
+	Reassigned    []ReassignedReview `json:"reassigned,omitempty"`
+	NotReassigned []FailedReassign   `json:"not_reassigned,omitempty"`
+	KeptReviews   []string           `json:"kept_reviews,omitempty"` // открытые ревью, оставшиеся за пользователем
+	Backfilled    []BackfilledPR     `json:"backfilled,omitempty"`   // pr новой команды, на которые добавлен пользователь
+	Error         string             `json:"error,omitempty"`        // перевод выполнен, но добор прерван
+}
+
+// ReviewHandoff - передача открытого ревью, выполняемая вместе с изменением состава команды
+type ReviewHandoff struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+	Reason        AssignmentReason
+}
+
+// UserCapacity - изменение лимита открытых ревью пользователя
+type UserCapacity struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"` // null или 0 снимает лимит
+}
+
+// UserSeniority - изменение уровня пользователя
+type UserSeniority struct {
+	UserID    string `json:"user_id"`
+	Seniority string `json:"seniority"`
+}
+
+// UserAdmin - изменение прав администратора пользователя
+type UserAdmin struct {
+	UserID  string `json:"user_id"`
+	IsAdmin bool   `json:"is_admin"`
+}
+
+// Absence - период отсутствия пользователя
+type Absence struct {
+	AbsenceID int64     `json:"absence_id"`
+	UserID    string    `json:"user_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason"`
+}
+
+// UserTags - теги экспертизы пользователя
+type UserTags struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
 }
 
 // Team - команда
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName             string       `json:"team_name"`
+	Members              []TeamMember `json:"members"`
+	AssignmentStrategy   string       `json:"assignment_strategy,omitempty"` // пусто - стратегия из конфига
+	ReviewersCount       int          `json:"reviewers_count"`               // сколько ревьюверов назначать на pr
+	FallbackTeams        []string     `json:"fallback_teams"`                // откуда добирать ревьюверов, по порядку
+	RequireSenior        bool         `json:"require_senior"`                // на каждом pr нужен хотя бы один senior
+	ReassignOnDeactivate bool         `json:"reassign_on_deactivate"`        // при деактивации передавать открытые ревью
+
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews,omitempty"` // лимит OPEN pr на ревью для участников без своего
+
+	MergePolicy MergePolicy `json:"merge_policy"`
+	SizeTiers   []SizeTier  `json:"size_tiers,omitempty"` // количество ревьюверов по размеру pr
+}
+
+// SizeTier - уровень размера pr и сколько ревьюверов ему нужно.
+// Уровень подходит pr, если достигнут хотя бы один его порог; уровень без порогов подходит любому pr с известным размером
+type SizeTier struct {
+	Name           string `json:"name"`
+	MinLines       int    `json:"min_lines,omitempty"` // добавлено + удалено строк
+	MinFiles       int    `json:"min_files,omitempty"` // изменённых файлов
+	ReviewersCount int    `json:"reviewers_count"`
+}
+
+// MergePolicy - условия, при которых pr авторов команды можно замержить
+type MergePolicy struct {
+	MinApprovals             int  `json:"min_approvals"`               // сколько ревьюверов должны одобрить pr
+	BlockOnChangesRequested  bool `json:"block_on_changes_requested"`  // нет ревью в состоянии CHANGES_REQUESTED
+	RequireSeniorApproval    bool `json:"require_senior_approval"`     // pr одобрил хотя бы один senior
+	RequireCodeOwnerApproval bool `json:"require_code_owner_approval"` // pr одобрил владелец изменённых файлов
+}
+
+// MergePolicySettings - изменение политики мержа, nil поле оставляет прежнее значение
+type MergePolicySettings struct {
+	MinApprovals             *int  `json:"min_approvals"`
+	BlockOnChangesRequested  *bool `json:"block_on_changes_requested"`
+	RequireSeniorApproval    *bool `json:"require_senior_approval"`
+	RequireCodeOwnerApproval *bool `json:"require_code_owner_approval"`
+}
+
+// TeamSettings - изменение настроек команды, nil поля не меняются
+type TeamSettings struct {
+	TeamName             string   `json:"team_name"`
+	AssignmentStrategy   *string  `json:"assignment_strategy"`
+	ReviewersCount       *int     `json:"reviewers_count"`
+	FallbackTeams        []string `json:"fallback_teams"` // пустой массив очищает список
+	RequireSenior        *bool    `json:"require_senior"`
+	ReassignOnDeactivate *bool    `json:"reassign_on_deactivate"`
+
+	DefaultMaxOpenReviews *int `json:"default_max_open_reviews"` // 0 снимает лимит
+
+	MergePolicy *MergePolicySettings `json:"merge_policy"`
+	SizeTiers   []SizeTier           `json:"size_tiers"` // пустой массив очищает список
 }
 
+// DefaultReviewersCount - количество ревьюверов, если команда его не задала
+const DefaultReviewersCount = 2
+
 // PullRequest - полная информация о pr
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`             // OPEN / MERGED
-	AssignedReviewers []string   `json:"assigned_reviewers"` // хранится в отдельной таблице pr_reviewers
-	CreatedAt         time.Time  `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	Status            PRStatus          `json:"status"`                       // DRAFT / OPEN / MERGED / CLOSED
+	AssignedReviewers []string          `json:"assigned_reviewers"`           // хранится в отдельной таблице pr_reviewers
+	FallbackReviewers map[string]string `json:"fallback_reviewers,omitempty"` // ревьювер -> резервная команда
+	Labels            []string          `json:"labels,omitempty"`             // хранится в отдельной таблице pr_labels
+	ReviewersCount    int               `json:"reviewers_count,omitempty"`    // сколько ревьюверов нужно pr, 0 - как в команде
+	SizeTier          string            `json:"size_tier,omitempty"`          // уровень размера, выбравший reviewers_count
+	TeamName          string            `json:"team_name,omitempty"`          // команда, для которой создан pr
+	CreatedAt         time.Time         `json:"createdAt,omitempty"`
+	MergedAt          *time.Time        `json:"mergedAt,omitempty"`
+	PRMetadata
+
+	AssignmentReason map[string]AssignmentReason `json:"assignment_reason,omitempty"` // ревьювер -> почему он выбран
+	ReviewStates     map[string]string           `json:"review_states,omitempty"`     // ревьювер -> состояние ревью
+	ChangedFiles     []string                    `json:"-"`                           // хранится в отдельной таблице pr_files
+}
+
+// MergeRequest - запрос на мерж pr
+type MergeRequest struct {
+	PRRef
+	Override       bool   `json:"override"`        // замержить, несмотря на невыполненные условия политики
+	OverrideBy     string `json:"override_by"`     // кто обходит политику
+	OverrideReason string `json:"override_reason"` // зачем
+}
+
+// Условия политики мержа
+const (
+	ConditionMinApprovals       = "min_approvals"
+	ConditionNoChangesRequested = "no_changes_requested"
+	ConditionSeniorApproval     = "senior_approval"
+	ConditionCodeOwnerApproval  = "code_owner_approval"
+)
+
+// UnmetCondition - невыполненное условие политики мержа
+type UnmetCondition struct {
+	Condition string `json:"condition"`
+	Message   string `json:"message"`
+}
+
+// MergeOverride - запись аудита о мерже в обход политики
+type MergeOverride struct {
+	OverrideID      int64            `json:"override_id"`
+	PullRequestID   string           `json:"pull_request_id"`
+	OverriddenBy    string           `json:"overridden_by"`
+	Reason          string           `json:"reason"`
+	UnmetConditions []UnmetCondition `json:"unmet_conditions"`
+	CreatedAt       time.Time        `json:"created_at"`
+}
+
+// MergeBlockedError - мерж запрещен политикой команды
+type MergeBlockedError struct {
+	Unmet []UnmetCondition
+}
+
+// Error - текст ошибки
+func (e *MergeBlockedError) Error() string {
+	return fmt.Sprintf("%s: %d unmet conditions", ErrMergeBlocked, len(e.Unmet))
+}
+
+// Is - MergeBlockedError соответствует ErrMergeBlocked
+func (e *MergeBlockedError) Is(target error) bool {
+	return target == ErrMergeBlocked
+}
+
+// AssignmentPreview - результат подбора ревьюверов без создания pr
+type AssignmentPreview struct {
+	AuthorID          string            `json:"author_id"`
+	TeamName          string            `json:"team_name"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	FallbackReviewers map[string]string `json:"fallback_reviewers,omitempty"`
+	Pool              []PoolMember      `json:"pool"`
+	SizeTier          string            `json:"size_tier,omitempty"`
+
+	AssignmentReason map[string]AssignmentReason `json:"assignment_reason,omitempty"`
+}
+
+// PoolMember - участник пула кандидатов и причина, по которой он не может быть выбран
+type PoolMember struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	TeamName    string `json:"team_name,omitempty"`
+	Source      string `json:"source"`       // code_owner / team / fallback
+	OpenReviews int    `json:"open_reviews"` // количество OPEN pr на ревью
+	Selected    bool   `json:"selected"`
+	Excluded    string `json:"excluded,omitempty"` // пусто - кандидат подходит
+}
+
+// Откуда кандидат попал в пул
+const (
+	PoolSourceCodeOwner = "code_owner"
+	PoolSourceTeam      = "team"
+	PoolSourceFallback  = "fallback"
+)
+
+// Причины исключения кандидата
+const (
+	ExcludedAuthor       = "author"
+	ExcludedInactive     = "inactive"
+	ExcludedAway         = "away"
+	ExcludedOverCapacity = "over_capacity"
+	ExcludedAssigned     = "already_assigned"
+	ExcludedNotSenior    = "not_senior"
+	ExcludedNoTeam       = "no_team" // владелец файлов не состоит ни в одной команде
+)
+
+// AssignmentReason - почему ревьювер был выбран
+type AssignmentReason struct {
+	Strategy       string            `json:"strategy"`
+	Source         string            `json:"source"`              // code_owner / team / fallback
+	TeamName       string            `json:"team_name,omitempty"` // команда, из которой выбран ревьювер
+	PoolSize       int               `json:"pool_size"`           // сколько кандидатов подходило
+	MatchedTags    bool              `json:"matched_tags"`        // выбран среди кандидатов с тегами под метки pr
+	SeniorRequired bool              `json:"senior_required,omitempty"`
+	Exclusions     map[string]string `json:"exclusions,omitempty"` // user_id -> причина исключения
+	Seed           int64             `json:"seed"`                 // сид случайности подбора
+}
+
+// PRStatus - статус pr
+type PRStatus string
+
+// Статусы pr.
+// Нагрузку ревьювера и его лимит открытых ревью составляют только OPEN pr: ревьюверы pr в других статусах
+// остаются в pr_reviewers для истории и переоткрытия, но ревьюверов не занимают
+const (
+	PRStatusDraft  PRStatus = "DRAFT"  // ревьюверы не назначаются, пока pr не готов
+	PRStatusOpen   PRStatus = "OPEN"   // ждет ревью
+	PRStatusMerged PRStatus = "MERGED" // замержен, конечный статус
+	PRStatusClosed PRStatus = "CLOSED" // заброшен, ревьюверы не заняты им; можно переоткрыть
+)
+
+// prTransitions - допустимые переходы между статусами pr
+var prTransitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+// CanTransitionTo - допустим ли переход pr из статуса s в next
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	for _, allowed := range prTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PullRequestCreate - запрос на создание pr
+type PullRequestCreate struct {
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ReviewersCount  *int     `json:"reviewers_count,omitempty"` // перекрывает настройку команды
+	ChangedFiles    []string `json:"changed_files,omitempty"`   // пути для подбора по CODEOWNERS
+	Labels          []string `json:"labels,omitempty"`          // ревьюверы с такими тегами в приоритете
+	Seed            *int64   `json:"seed,omitempty"`            // сид прошлого подбора, чтобы его повторить
+	Draft           bool     `json:"draft,omitempty"`           // создать черновик без ревьюверов
+	TeamName        string   `json:"team_name,omitempty"`       // для какой команды pr, по умолчанию - основная команда автора
+	PRMetadata
+}
+
+// PRMetadata - необязательные сведения о pr: где он и какого размера
+type PRMetadata struct {
+	Repository        string `json:"repository,omitempty"`
+	Number            *int   `json:"number,omitempty"` // номер pr в репозитории, вместе с repository однозначно задает pr
+	SourceBranch      string `json:"source_branch,omitempty"`
+	TargetBranch      string `json:"target_branch,omitempty"`
+	URL               string `json:"url,omitempty"`
+	Additions         *int   `json:"additions,omitempty"`           // добавлено строк
+	Deletions         *int   `json:"deletions,omitempty"`           // удалено строк
+	ChangedFilesCount *int   `json:"changed_files_count,omitempty"` // по умолчанию - количество changed_files
+}
+
+// PrReviewerChange - запрос на добавление или удаление ревьювера pr
+type PrReviewerChange struct {
+	PRRef
+	ReviewerID string `json:"reviewer_id"`
+}
+
+// PRRef - ссылка на pr: pull_request_id или пара repository + number
+type PRRef struct {
+	PullRequestID string `json:"pull_request_id" form:"pull_request_id"`
+	Repository    string `json:"repository,omitempty" form:"repository"`
+	Number        *int   `json:"number,omitempty" form:"number"`
+}
+
+// Repository - репозиторий и команды, которым он принадлежит
+type Repository struct {
+	RepositoryName string   `json:"repository_name"`
+	Teams          []string `json:"teams"`
 }
 
 // PullRequestShort - сокращенный pr
 type PullRequestShort struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-	Status          string `json:"status"` // OPEN / MERGED
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	Status          PRStatus `json:"status"`                 // DRAFT / OPEN / MERGED / CLOSED
+	ReviewState     string   `json:"review_state,omitempty"` // состояние ревью пользователя, в ответе getReview
+	TeamName        string   `json:"team_name,omitempty"`    // команда, для которой создан pr
+	PRMetadata
+}
+
+// Состояния ревью
+const (
+	ReviewStatePending          = "PENDING"
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	ReviewStateDismissed        = "DISMISSED"
+)
+
+// ReviewSubmit - запрос на отправку ревью
+type ReviewSubmit struct {
+	PRRef
+	ReviewerID string `json:"reviewer_id"`
+	State      string `json:"state"` // APPROVED / CHANGES_REQUESTED / DISMISSED
+}
+
+// CodeOwnerRule - правило владения файлами из CODEOWNERS
+type CodeOwnerRule struct {
+	Line    int      `json:"line"`    // номер строки в импортированном файле
+	Pattern string   `json:"pattern"` // glob в синтаксисе CODEOWNERS
+	Users   []string `json:"users"`
+	Teams   []string `json:"teams"`
+}
+
+// CodeOwnersImport - результат импорта CODEOWNERS
+type CodeOwnersImport struct {
+	Rules         []CodeOwnerRule `json:"rules"`
+	UnknownOwners []string        `json:"unknown_owners"` // владельцы, не найденные среди пользователей и команд
 }
 
 // ErrorResponse - ответ ошибки
@@ -54,12 +486,41 @@ type ErrorResponse struct {
 type ErrorDetail struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+
+	UnmetConditions []UnmetCondition `json:"unmet_conditions,omitempty"` // для MERGE_BLOCKED
 }
 
 // Ошибки
 var (
-	ErrTeamExists = errors.New("team already exists")
-	ErrNotFound   = errors.New("user not found")
-	ErrPrExists   = errors.New("PR id already exists")
-	ErrPrMerged   = errors.New("cannot reassign on merged PR")
+	ErrTeamExists         = errors.New("team already exists")
+	ErrNotFound           = errors.New("user not found")
+	ErrPrExists           = errors.New("PR id already exists")
+	ErrPrMerged           = errors.New("cannot reassign on merged PR")
+	ErrUnknownStrategy    = errors.New("unknown assignment strategy")
+	ErrInvalidReviewers   = errors.New("reviewers count must be positive")
+	ErrInvalidFallback    = errors.New("invalid fallback team")
+	ErrInvalidCodeOwners  = errors.New("CODEOWNERS content is empty")
+	ErrInvalidSeniority   = errors.New("seniority must be junior, middle or senior")
+	ErrNoSeniorReviewer   = errors.New("no active senior reviewer available")
+	ErrInvalidCapacity    = errors.New("max open reviews must not be negative")
+	ErrInvalidAbsence     = errors.New("absence must end after it starts")
+	ErrNotCandidate       = errors.New("user cannot review this PR")
+	ErrAlreadyAssigned    = errors.New("user is already assigned to this PR")
+	ErrNotAssigned        = errors.New("user is not assigned to this PR")
+	ErrNoCandidate        = errors.New("no replacement candidate available")
+	ErrInvalidPRMetadata  = errors.New("additions, deletions and changed files count must not be negative")
+	ErrPrNotOpen          = errors.New("PR is not open")
+	ErrInvalidTransition  = errors.New("invalid PR status transition")
+	ErrMergeBlocked       = errors.New("merge blocked by team policy")
+	ErrInvalidMergePolicy = errors.New("min approvals must not be negative")
+	ErrInvalidOverride    = errors.New("override requires override_by and override_reason")
+	ErrForbidden          = errors.New("user is not an admin")
+	ErrInvalidReviewState = errors.New("review state must be PENDING, APPROVED, CHANGES_REQUESTED or DISMISSED")
+	ErrInvalidSizeTier    = errors.New("invalid size tier")
+	ErrInvalidPRRef       = errors.New("pull_request_id or repository and positive number are required")
+	ErrUserExists         = errors.New("user already belongs to this team")
+	ErrHasOpenReviews     = errors.New("user has open reviews")
+	ErrSameTeam           = errors.New("user is already in this team")
+	ErrTeamRequired       = errors.New("from_team is required for a user in several teams")
+	ErrNotTeamMember      = errors.New("author is not a member of the team")
 )
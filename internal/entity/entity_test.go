@@ -0,0 +1,34 @@
+package entity
+
+import "testing"
+
+func TestPRStatusCanTransitionTo(t *testing.T) {
+	statuses := []PRStatus{PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed}
+
+	allowed := map[PRStatus][]PRStatus{
+		PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+		PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+		PRStatusClosed: {PRStatusOpen},
+	}
+
+	// проверяем все пары статусов: разрешены только перечисленные переходы
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, next := range allowed[from] {
+				if next == to {
+					want = true
+				}
+			}
+
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+
+	// неизвестный статус никуда не переходит
+	if PRStatus("UNKNOWN").CanTransitionTo(PRStatusOpen) {
+		t.Errorf("UNKNOWN.CanTransitionTo(OPEN) = true, want false")
+	}
+}
@@ -44,6 +44,55 @@ func (h *Handler) CreateTeam(ctx *gin.Context) {
 					Message: "team_name already exists",
 				},
 			})
+		} else if errors.Is(err, entity.ErrUnknownStrategy) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "UNKNOWN_STRATEGY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidReviewers) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_REVIEWERS_COUNT",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidSeniority) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_SENIORITY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidCapacity) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_CAPACITY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidMergePolicy) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_MERGE_POLICY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidSizeTier) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_SIZE_TIER",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidFallback) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_FALLBACK_TEAM",
+					Message: err.Error(),
+				},
+			})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
@@ -89,11 +138,11 @@ func (h *Handler) GetTeam(ctx *gin.Context) {
 	})
 }
 
-// SetIsActive - изменить активность
-func (h *Handler) SetIsActive(ctx *gin.Context) {
-	var user entity.User
+// UpdateTeamSettings - изменить настройки команды
+func (h *Handler) UpdateTeamSettings(ctx *gin.Context) {
+	var settings entity.TeamSettings
 
-	if err := ctx.ShouldBindJSON(&user); err != nil {
+	if err := ctx.ShouldBindJSON(&settings); err != nil {
 		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
 			Error: entity.ErrorDetail{
 				Code:    "400",
@@ -103,15 +152,57 @@ func (h *Handler) SetIsActive(ctx *gin.Context) {
 		return
 	}
 
-	data, err := h.uc.ChangeActivityUser(ctx, user)
+	team, err := h.uc.UpdateTeamSettings(ctx, settings)
 	if err != nil {
-		if data == nil {
+		if errors.Is(err, entity.ErrNotFound) {
 			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
 					Code:    "NOT_FOUND",
 					Message: "resource not found",
 				},
 			})
+		} else if errors.Is(err, entity.ErrUnknownStrategy) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "UNKNOWN_STRATEGY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidReviewers) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_REVIEWERS_COUNT",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidCapacity) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_CAPACITY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidMergePolicy) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_MERGE_POLICY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidSizeTier) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_SIZE_TIER",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidFallback) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_FALLBACK_TEAM",
+					Message: err.Error(),
+				},
+			})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
@@ -123,32 +214,78 @@ func (h *Handler) SetIsActive(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"user_id": data.UserID, "is_active": data.IsActive})
+	ctx.JSON(http.StatusOK, gin.H{
+		"team": team,
+	})
 }
 
-// GetReview - получить pr-ы где пользователь reviewer
-func (h *Handler) GetReview(ctx *gin.Context) {
-	userID := ctx.Query("user_id")
+// AddTeamMember - добавить участника в команду
+func (h *Handler) AddTeamMember(ctx *gin.Context) {
+	var member entity.TeamMemberAdd
 
-	pr, err := h.uc.GetReviewFromUser(ctx, userID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+	if err := ctx.ShouldBindJSON(&member); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
 			Error: entity.ErrorDetail{
-				Code:    "INTERNAL_ERROR",
+				Code:    "400",
 				Message: err.Error(),
 			},
 		})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"user_id": userID, "pull_requests": pr})
+	result, err := h.uc.AddTeamMember(ctx, member)
+	if err != nil {
+		// result != nil - участник добавлен, ошибка в доборе
+		if result != nil {
+			result.Error = err.Error()
+			ctx.JSON(http.StatusMultiStatus, result)
+		} else if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrUserExists) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "USER_EXISTS",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidSeniority) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_SENIORITY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidCapacity) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_CAPACITY",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
 }
 
-// PullRequestCreate - создание pr-а
-func (h *Handler) PullRequestCreate(ctx *gin.Context) {
-	var pr entity.PullRequestShort
+// UpdateTeamMember - изменить участника команды
+func (h *Handler) UpdateTeamMember(ctx *gin.Context) {
+	var update entity.TeamMemberUpdate
 
-	if err := ctx.ShouldBindJSON(&pr); err != nil {
+	if err := ctx.ShouldBindJSON(&update); err != nil {
 		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
 			Error: entity.ErrorDetail{
 				Code:    "400",
@@ -158,7 +295,7 @@ func (h *Handler) PullRequestCreate(ctx *gin.Context) {
 		return
 	}
 
-	fullPr, err := h.uc.CreatePullRequest(ctx, pr)
+	result, err := h.uc.UpdateTeamMember(ctx, update)
 	if err != nil {
 		if errors.Is(err, entity.ErrNotFound) {
 			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
@@ -167,11 +304,18 @@ func (h *Handler) PullRequestCreate(ctx *gin.Context) {
 					Message: "resource not found",
 				},
 			})
-		} else if errors.Is(err, entity.ErrPrExists) {
-			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+		} else if errors.Is(err, entity.ErrInvalidSeniority) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
-					Code:    "PR_EXISTS",
-					Message: "PR id already exists",
+					Code:    "INVALID_SENIORITY",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidCapacity) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_CAPACITY",
+					Message: err.Error(),
 				},
 			})
 		} else {
@@ -185,14 +329,14 @@ func (h *Handler) PullRequestCreate(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{"pr": fullPr})
+	ctx.JSON(http.StatusOK, result)
 }
 
-// MergePR - замержить pr
-func (h *Handler) MergePR(ctx *gin.Context) {
-	var pr entity.PullRequestShort
+// RemoveTeamMember - убрать участника из команды
+func (h *Handler) RemoveTeamMember(ctx *gin.Context) {
+	var remove entity.TeamMemberRemove
 
-	if err := ctx.ShouldBindJSON(&pr); err != nil {
+	if err := ctx.ShouldBindJSON(&remove); err != nil {
 		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
 			Error: entity.ErrorDetail{
 				Code:    "400",
@@ -202,7 +346,7 @@ func (h *Handler) MergePR(ctx *gin.Context) {
 		return
 	}
 
-	mergedPr, err := h.uc.MergePr(ctx, pr.PullRequestID)
+	result, err := h.uc.RemoveTeamMember(ctx, remove)
 	if err != nil {
 		if errors.Is(err, entity.ErrNotFound) {
 			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
@@ -211,6 +355,13 @@ func (h *Handler) MergePR(ctx *gin.Context) {
 					Message: "resource not found",
 				},
 			})
+		} else if errors.Is(err, entity.ErrHasOpenReviews) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "HAS_OPEN_REVIEWS",
+					Message: err.Error(),
+				},
+			})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
@@ -222,17 +373,41 @@ func (h *Handler) MergePR(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"pr": mergedPr})
+	ctx.JSON(http.StatusOK, result)
 }
 
-// ReassignPrReviewer - Переназначить конкретного ревьювера на другого из его команды
-func (h *Handler) ReassignPrReviewer(ctx *gin.Context) {
-	var req struct {
-		PullRequestID string `json:"pull_request_id"`
-		OldUserID     string `json:"old_reviewer_id"`
+// GetTeamAbsences - текущие и предстоящие отсутствия участников команды
+func (h *Handler) GetTeamAbsences(ctx *gin.Context) {
+	teamName := ctx.Query("team_name")
+
+	absences, err := h.uc.GetTeamAbsences(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
 	}
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
+	ctx.JSON(http.StatusOK, gin.H{"team_name": teamName, "absences": absences})
+}
+
+// SetIsActive - изменить активность
+func (h *Handler) SetIsActive(ctx *gin.Context) {
+	var user entity.UserActivity
+
+	if err := ctx.ShouldBindJSON(&user); err != nil {
 		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
 			Error: entity.ErrorDetail{
 				Code:    "400",
@@ -242,23 +417,102 @@ func (h *Handler) ReassignPrReviewer(ctx *gin.Context) {
 		return
 	}
 
-	pr, newReviewerID, err := h.uc.ReassignPrReviewer(ctx, req.PullRequestID, req.OldUserID)
+	data, err := h.uc.ChangeActivityUser(ctx, user)
 	if err != nil {
-		if errors.Is(err, entity.ErrNotFound) {
+		// data != nil - активность сохранена, ошибка в последующих изменениях: возвращаем то, что успели сделать
+		if data != nil {
+			data.Error = err.Error()
+			ctx.JSON(http.StatusMultiStatus, data)
+		} else if errors.Is(err, entity.ErrNotFound) {
 			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
 					Code:    "NOT_FOUND",
 					Message: "resource not found",
 				},
 			})
-		} else if errors.Is(err, entity.ErrPrMerged) {
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, data)
+}
+
+// MoveUserTeam - перевести пользователя в другую команду
+func (h *Handler) MoveUserTeam(ctx *gin.Context) {
+	var move entity.UserMove
+
+	if err := ctx.ShouldBindJSON(&move); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	result, err := h.uc.MoveUserTeam(ctx, move)
+	if err != nil {
+		// result != nil - пользователь переведен, ошибка в доборе
+		if result != nil {
+			result.Error = err.Error()
+			ctx.JSON(http.StatusMultiStatus, result)
+		} else if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrSameTeam) {
 			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
-					Code:    "PR_MERGED",
-					Message: "cannot reassign on merged PR",
+					Code:    "SAME_TEAM",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrTeamRequired) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "TEAM_REQUIRED",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
 				},
 			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetReview - получить pr-ы где пользователь reviewer
+func (h *Handler) GetReview(ctx *gin.Context) {
+	userID := ctx.Query("user_id")
+	state := ctx.Query("state")
 
+	pr, err := h.uc.GetReviewFromUser(ctx, userID, state)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidReviewState) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_REVIEW_STATE",
+					Message: err.Error(),
+				},
+			})
 		} else {
 			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
 				Error: entity.ErrorDetail{
@@ -270,5 +524,1312 @@ func (h *Handler) ReassignPrReviewer(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"pr": pr, "replaced_by": newReviewerID})
+	ctx.JSON(http.StatusOK, gin.H{"user_id": userID, "pull_requests": pr})
+}
+
+// AddAbsence - зарегистрировать период отсутствия
+func (h *Handler) AddAbsence(ctx *gin.Context) {
+	var absence entity.Absence
+
+	if err := ctx.ShouldBindJSON(&absence); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.AddAbsence(ctx, absence)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidAbsence) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_ABSENCE",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"absence": data})
+}
+
+// GetAbsences - получить периоды отсутствия пользователя
+func (h *Handler) GetAbsences(ctx *gin.Context) {
+	userID := ctx.Query("user_id")
+
+	absences, err := h.uc.GetUserAbsences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"user_id": userID, "absences": absences})
+}
+
+// UpdateAbsence - изменить период отсутствия
+func (h *Handler) UpdateAbsence(ctx *gin.Context) {
+	var absence entity.Absence
+
+	if err := ctx.ShouldBindJSON(&absence); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.UpdateAbsence(ctx, absence)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidAbsence) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_ABSENCE",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"absence": data})
+}
+
+// DeleteAbsence - удалить период отсутствия
+func (h *Handler) DeleteAbsence(ctx *gin.Context) {
+	var req struct {
+		AbsenceID int64 `json:"absence_id"`
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	err := h.uc.DeleteAbsence(ctx, req.AbsenceID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"absence_id": req.AbsenceID})
+}
+
+// SetSeniority - изменить уровень пользователя
+func (h *Handler) SetSeniority(ctx *gin.Context) {
+	var user entity.UserSeniority
+
+	if err := ctx.ShouldBindJSON(&user); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.SetUserSeniority(ctx, user)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidSeniority) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_SENIORITY",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, data)
+}
+
+// SetIsAdmin - выдать или забрать права администратора
+func (h *Handler) SetIsAdmin(ctx *gin.Context) {
+	var user entity.UserAdmin
+
+	if err := ctx.ShouldBindJSON(&user); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.SetUserAdmin(ctx, user)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, data)
+}
+
+// SetMaxOpenReviews - изменить лимит открытых ревью пользователя
+func (h *Handler) SetMaxOpenReviews(ctx *gin.Context) {
+	var user entity.UserCapacity
+
+	if err := ctx.ShouldBindJSON(&user); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.SetUserMaxOpenReviews(ctx, user)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidCapacity) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_CAPACITY",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, data)
+}
+
+// AddUserTags - добавить теги экспертизы пользователю
+func (h *Handler) AddUserTags(ctx *gin.Context) {
+	var userTags entity.UserTags
+
+	if err := ctx.ShouldBindJSON(&userTags); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.AddUserTags(ctx, userTags)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, data)
+}
+
+// RemoveUserTags - убрать теги экспертизы у пользователя
+func (h *Handler) RemoveUserTags(ctx *gin.Context) {
+	var userTags entity.UserTags
+
+	if err := ctx.ShouldBindJSON(&userTags); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	data, err := h.uc.RemoveUserTags(ctx, userTags)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, data)
+}
+
+// PullRequestCreate - создание pr-а
+func (h *Handler) PullRequestCreate(ctx *gin.Context) {
+	var pr entity.PullRequestCreate
+
+	if err := ctx.ShouldBindJSON(&pr); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	fullPr, err := h.uc.CreatePullRequest(ctx, pr)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrExists) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_EXISTS",
+					Message: "PR id already exists",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidReviewers) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_REVIEWERS_COUNT",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidPRRef) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_PR_REF",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidPRMetadata) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_PR_METADATA",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotTeamMember) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_TEAM_MEMBER",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNoSeniorReviewer) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NO_SENIOR_REVIEWER",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"pr": fullPr})
+}
+
+// PreviewPullRequest - подбор ревьюверов без создания pr
+func (h *Handler) PreviewPullRequest(ctx *gin.Context) {
+	var pr entity.PullRequestCreate
+
+	if err := ctx.ShouldBindJSON(&pr); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	preview, err := h.uc.PreviewPullRequest(ctx, pr)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidReviewers) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_REVIEWERS_COUNT",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidPRRef) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_PR_REF",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidPRMetadata) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_PR_METADATA",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotTeamMember) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_TEAM_MEMBER",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNoSeniorReviewer) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NO_SENIOR_REVIEWER",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
+// GetPullRequest - получить pr
+func (h *Handler) GetPullRequest(ctx *gin.Context) {
+	var ref entity.PRRef
+
+	if err := ctx.ShouldBindQuery(&ref); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, ref)
+	if !ok {
+		return
+	}
+
+	pr, err := h.uc.GetPullRequest(ctx, prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+// MergePR - замержить pr
+func (h *Handler) MergePR(ctx *gin.Context) {
+	var req entity.MergeRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, req.PRRef)
+	if !ok {
+		return
+	}
+	req.PullRequestID = prID
+
+	mergedPr, err := h.uc.MergePr(ctx, req)
+	if err != nil {
+		var blocked *entity.MergeBlockedError
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.As(err, &blocked) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:            "MERGE_BLOCKED",
+					Message:         entity.ErrMergeBlocked.Error(),
+					UnmetConditions: blocked.Unmet,
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_TRANSITION",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidOverride) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_OVERRIDE",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "FORBIDDEN",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": mergedPr})
+}
+
+// MarkReady - черновик готов к ревью
+func (h *Handler) MarkReady(ctx *gin.Context) {
+	var ref entity.PRRef
+
+	if err := ctx.ShouldBindJSON(&ref); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, ref)
+	if !ok {
+		return
+	}
+
+	updatedPr, err := h.uc.MarkReady(ctx, prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_TRANSITION",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNoSeniorReviewer) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NO_SENIOR_REVIEWER",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": updatedPr})
+}
+
+// ClosePR - закрыть pr без мержа
+func (h *Handler) ClosePR(ctx *gin.Context) {
+	var ref entity.PRRef
+
+	if err := ctx.ShouldBindJSON(&ref); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, ref)
+	if !ok {
+		return
+	}
+
+	updatedPr, err := h.uc.ClosePr(ctx, prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_TRANSITION",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": updatedPr})
+}
+
+// ReopenPR - переоткрыть закрытый pr
+func (h *Handler) ReopenPR(ctx *gin.Context) {
+	var ref entity.PRRef
+
+	if err := ctx.ShouldBindJSON(&ref); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, ref)
+	if !ok {
+		return
+	}
+
+	updatedPr, err := h.uc.ReopenPr(ctx, prID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidTransition) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_TRANSITION",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNoSeniorReviewer) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NO_SENIOR_REVIEWER",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": updatedPr})
+}
+
+// ReassignPrReviewer - Переназначить конкретного ревьювера на другого из его команды
+func (h *Handler) ReassignPrReviewer(ctx *gin.Context) {
+	var req struct {
+		entity.PRRef
+		OldUserID string `json:"old_reviewer_id"`
+		NewUserID string `json:"new_reviewer_id"` // необязательный, пусто - подобрать замену
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, req.PRRef)
+	if !ok {
+		return
+	}
+
+	pr, newReviewerID, err := h.uc.ReassignPrReviewer(ctx, prID, req.OldUserID, req.NewUserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrMerged) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_MERGED",
+					Message: "cannot reassign on merged PR",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrNotOpen) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_NOT_OPEN",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNoSeniorReviewer) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NO_SENIOR_REVIEWER",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotCandidate) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_CANDIDATE",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrAlreadyAssigned) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "ALREADY_ASSIGNED",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotAssigned) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_ASSIGNED",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNoCandidate) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NO_CANDIDATE",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": pr, "replaced_by": newReviewerID})
+}
+
+// AddReviewer - добавить ревьювера на pr
+func (h *Handler) AddReviewer(ctx *gin.Context) {
+	var change entity.PrReviewerChange
+
+	if err := ctx.ShouldBindJSON(&change); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, change.PRRef)
+	if !ok {
+		return
+	}
+	change.PullRequestID = prID
+
+	pr, err := h.uc.AddReviewer(ctx, change)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrMerged) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_MERGED",
+					Message: "cannot change reviewers on merged PR",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrNotOpen) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_NOT_OPEN",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrAlreadyAssigned) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "ALREADY_ASSIGNED",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotCandidate) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_CANDIDATE",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+// RemoveReviewer - снять ревьювера с pr
+func (h *Handler) RemoveReviewer(ctx *gin.Context) {
+	var change entity.PrReviewerChange
+
+	if err := ctx.ShouldBindJSON(&change); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, change.PRRef)
+	if !ok {
+		return
+	}
+	change.PullRequestID = prID
+
+	pr, err := h.uc.RemoveReviewer(ctx, change)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrMerged) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_MERGED",
+					Message: "cannot change reviewers on merged PR",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrNotOpen) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_NOT_OPEN",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotAssigned) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_ASSIGNED",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+// SubmitReview - отправить ревью
+func (h *Handler) SubmitReview(ctx *gin.Context) {
+	var review entity.ReviewSubmit
+
+	if err := ctx.ShouldBindJSON(&review); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	prID, ok := h.resolvePullRequestID(ctx, review.PRRef)
+	if !ok {
+		return
+	}
+	review.PullRequestID = prID
+
+	pr, err := h.uc.SubmitReview(ctx, review)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrMerged) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_MERGED",
+					Message: "cannot review merged PR",
+				},
+			})
+		} else if errors.Is(err, entity.ErrPrNotOpen) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "PR_NOT_OPEN",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrNotAssigned) {
+			ctx.JSON(http.StatusConflict, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_ASSIGNED",
+					Message: err.Error(),
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidReviewState) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_REVIEW_STATE",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"pr": pr})
+}
+
+// ImportCodeOwners - импортировать файл CODEOWNERS
+func (h *Handler) ImportCodeOwners(ctx *gin.Context) {
+	var req struct {
+		Content string `json:"content"`
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	result, err := h.uc.ImportCodeOwners(ctx, req.Content)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCodeOwners) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_CODEOWNERS",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetCodeOwners - получить правила CODEOWNERS
+func (h *Handler) GetCodeOwners(ctx *gin.Context) {
+	rules, err := h.uc.GetCodeOwners(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// BackfillReviewers - добрать ревьюверов на OPEN pr команды
+func (h *Handler) BackfillReviewers(ctx *gin.Context) {
+	var req entity.BackfillRequest
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	result, err := h.uc.BackfillTeam(ctx, req.TeamName)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GetMergeOverrides - аудит мержей в обход политики
+func (h *Handler) GetMergeOverrides(ctx *gin.Context) {
+	var ref entity.PRRef
+
+	if err := ctx.ShouldBindQuery(&ref); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	// без ссылки на pr возвращается весь аудит
+	var prID string
+	if ref.PullRequestID != "" || ref.Repository != "" || ref.Number != nil {
+		var ok bool
+		prID, ok = h.resolvePullRequestID(ctx, ref)
+		if !ok {
+			return
+		}
+	}
+
+	overrides, err := h.uc.GetMergeOverrides(ctx, prID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"overrides": overrides})
+}
+
+// resolvePullRequestID - pull_request_id по ссылке на pr, при ошибке ответ уже записан
+func (h *Handler) resolvePullRequestID(ctx *gin.Context, ref entity.PRRef) (string, bool) {
+	prID, err := h.uc.ResolvePullRequestID(ctx, ref)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else if errors.Is(err, entity.ErrInvalidPRRef) {
+			ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INVALID_PR_REF",
+					Message: err.Error(),
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return "", false
+	}
+
+	return prID, true
+}
+
+// SaveRepository - зарегистрировать репозиторий и задать его команды
+func (h *Handler) SaveRepository(ctx *gin.Context) {
+	var repository entity.Repository
+
+	if err := ctx.ShouldBindJSON(&repository); err != nil {
+		ctx.JSON(http.StatusBadRequest, entity.ErrorResponse{
+			Error: entity.ErrorDetail{
+				Code:    "400",
+				Message: err.Error(),
+			},
+		})
+		return
+	}
+
+	saved, err := h.uc.SaveRepository(ctx, repository)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"repository": saved})
+}
+
+// GetRepository - получить репозиторий и его команды
+func (h *Handler) GetRepository(ctx *gin.Context) {
+	repositoryName := ctx.Query("repository_name")
+
+	repository, err := h.uc.GetRepository(ctx, repositoryName)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, entity.ErrorResponse{
+				Error: entity.ErrorDetail{
+					Code:    "INTERNAL_ERROR",
+					Message: err.Error(),
+				},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"repository": repository})
 }
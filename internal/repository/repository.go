@@ -56,19 +56,38 @@ func (repo *Repository) CreateTeam(ctx context.Context, team entity.Team) error
 		}
 	}()
 
-	_, err = tx.Exec(ctx, `INSERT INTO team (team_name) VALUES ($1)`, team.TeamName)
+	_, err = tx.Exec(ctx, `INSERT INTO team (team_name, assignment_strategy, reviewers_count, require_senior,
+		default_max_open_reviews, reassign_on_deactivate, min_approvals, block_on_changes_requested,
+		require_senior_approval, require_code_owner_approval)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10)`,
+		team.TeamName, team.AssignmentStrategy, team.ReviewersCount, team.RequireSenior, team.DefaultMaxOpenReviews,
+		team.ReassignOnDeactivate, team.MergePolicy.MinApprovals, team.MergePolicy.BlockOnChangesRequested,
+		team.MergePolicy.RequireSeniorApproval, team.MergePolicy.RequireCodeOwnerApproval)
 	if err != nil {
 		repo.Logger.Error("Error insert into team", zap.Error(err))
 		return err
 	}
 
 	for _, member := range team.Members {
-		_, err := tx.Exec(ctx, `INSERT INTO users (team_name, user_id, username, is_active) VALUES ($1, $2, $3, $4)`,
-			team.TeamName, member.UserID, member.Username, member.IsActive)
+		err = repo.saveMemberUser(ctx, tx, member)
 		if err != nil {
-			repo.Logger.Error("Error insert into team_member", zap.Error(err))
 			return err
 		}
+
+		err = repo.insertTeamMember(ctx, tx, team.TeamName, member.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = repo.insertFallbackTeams(ctx, tx, team.TeamName, team.FallbackTeams)
+	if err != nil {
+		return err
+	}
+
+	err = repo.insertSizeTiers(ctx, tx, team.TeamName, team.SizeTiers)
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -81,8 +100,32 @@ func (repo *Repository) GetTeam(ctx context.Context, teamName string) (*entity.T
 
 	team.TeamName = teamName
 
-	rows, err := repo.DB.Query(ctx, `SELECT user_id, username, is_active FROM users
-    	WHERE team_name = $1`, teamName)
+	var strategy *string
+	err := repo.DB.QueryRow(ctx, `SELECT assignment_strategy, reviewers_count, require_senior, default_max_open_reviews,
+		reassign_on_deactivate, min_approvals, block_on_changes_requested, require_senior_approval,
+		require_code_owner_approval
+		FROM team WHERE team_name = $1`,
+		teamName).Scan(&strategy, &team.ReviewersCount, &team.RequireSenior, &team.DefaultMaxOpenReviews,
+		&team.ReassignOnDeactivate, &team.MergePolicy.MinApprovals, &team.MergePolicy.BlockOnChangesRequested,
+		&team.MergePolicy.RequireSeniorApproval, &team.MergePolicy.RequireCodeOwnerApproval)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &team, nil
+		}
+		repo.Logger.Error("Error select team settings", zap.Error(err))
+		return nil, err
+	}
+
+	if strategy != nil {
+		team.AssignmentStrategy = *strategy
+	}
+
+	rows, err := repo.DB.Query(ctx, `SELECT u.user_id, u.username, u.is_active, u.seniority, u.max_open_reviews,
+		EXISTS (SELECT 1 FROM user_absences a
+			WHERE a.user_id = u.user_id AND a.starts_at <= NOW() AND a.ends_at > NOW())
+		FROM team_members tm
+		JOIN users u ON u.user_id = tm.user_id
+		WHERE tm.team_name = $1`, teamName)
 	if err != nil {
 		repo.Logger.Error("Error select from team", zap.Error(err))
 		return nil, err
@@ -91,18 +134,179 @@ func (repo *Repository) GetTeam(ctx context.Context, teamName string) (*entity.T
 
 	for rows.Next() {
 		var m entity.TeamMember
-		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive); err != nil {
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.Seniority, &m.MaxOpenReviews, &m.IsAway); err != nil {
 			repo.Logger.Error("Error scan from team", zap.Error(err))
 			return nil, err
 		}
 		members = append(members, m)
 	}
 
+	tagRows, err := repo.DB.Query(ctx, `SELECT t.user_id, t.tag FROM user_tags t
+		JOIN team_members tm ON tm.user_id = t.user_id
+		WHERE tm.team_name = $1 ORDER BY t.tag`, teamName)
+	if err != nil {
+		repo.Logger.Error("Error select user tags", zap.Error(err))
+		return nil, err
+	}
+	defer tagRows.Close()
+
+	tags := make(map[string][]string)
+	for tagRows.Next() {
+		var userID, tag string
+		if err := tagRows.Scan(&userID, &tag); err != nil {
+			repo.Logger.Error("Error scan user tag", zap.Error(err))
+			return nil, err
+		}
+		tags[userID] = append(tags[userID], tag)
+	}
+
+	for i := range members {
+		members[i].Tags = tags[members[i].UserID]
+	}
+
 	team.Members = members
 
+	fallbackRows, err := repo.DB.Query(ctx, `SELECT fallback_team_name FROM team_fallbacks
+		WHERE team_name = $1 ORDER BY position`, teamName)
+	if err != nil {
+		repo.Logger.Error("Error select fallback teams", zap.Error(err))
+		return nil, err
+	}
+	defer fallbackRows.Close()
+
+	for fallbackRows.Next() {
+		var fallbackName string
+		if err := fallbackRows.Scan(&fallbackName); err != nil {
+			repo.Logger.Error("Error scan fallback team", zap.Error(err))
+			return nil, err
+		}
+		team.FallbackTeams = append(team.FallbackTeams, fallbackName)
+	}
+
+	tierRows, err := repo.DB.Query(ctx, `SELECT name, min_lines, min_files, reviewers_count FROM team_size_tiers
+		WHERE team_name = $1 ORDER BY position`, teamName)
+	if err != nil {
+		repo.Logger.Error("Error select size tiers", zap.Error(err))
+		return nil, err
+	}
+	defer tierRows.Close()
+
+	for tierRows.Next() {
+		var tier entity.SizeTier
+		if err := tierRows.Scan(&tier.Name, &tier.MinLines, &tier.MinFiles, &tier.ReviewersCount); err != nil {
+			repo.Logger.Error("Error scan size tier", zap.Error(err))
+			return nil, err
+		}
+		team.SizeTiers = append(team.SizeTiers, tier)
+	}
+
 	return &team, nil
 }
 
+// UpdateTeamSettings - изменить настройки команды
+func (repo *Repository) UpdateTeamSettings(ctx context.Context, settings entity.TeamSettings) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	policy := settings.MergePolicy
+	if policy == nil {
+		policy = &entity.MergePolicySettings{}
+	}
+
+	// nil параметр оставляет прежнее значение, пустая строка сбрасывает его
+	_, err = tx.Exec(ctx, `UPDATE team
+		SET assignment_strategy = NULLIF(COALESCE($2, assignment_strategy), ''),
+			reviewers_count = COALESCE($3, reviewers_count),
+			require_senior = COALESCE($4, require_senior),
+			default_max_open_reviews = NULLIF(COALESCE($5, default_max_open_reviews, 0), 0),
+			reassign_on_deactivate = COALESCE($6, reassign_on_deactivate),
+			min_approvals = COALESCE($7, min_approvals),
+			block_on_changes_requested = COALESCE($8, block_on_changes_requested),
+			require_senior_approval = COALESCE($9, require_senior_approval),
+			require_code_owner_approval = COALESCE($10, require_code_owner_approval)
+		WHERE team_name = $1`,
+		settings.TeamName, settings.AssignmentStrategy, settings.ReviewersCount, settings.RequireSenior,
+		settings.DefaultMaxOpenReviews, settings.ReassignOnDeactivate, policy.MinApprovals,
+		policy.BlockOnChangesRequested, policy.RequireSeniorApproval, policy.RequireCodeOwnerApproval)
+	if err != nil {
+		repo.Logger.Error("Error update team settings", zap.Error(err))
+		return err
+	}
+
+	if settings.FallbackTeams != nil {
+		_, err = tx.Exec(ctx, `DELETE FROM team_fallbacks WHERE team_name = $1`, settings.TeamName)
+		if err != nil {
+			repo.Logger.Error("Error delete fallback teams", zap.Error(err))
+			return err
+		}
+
+		err = repo.insertFallbackTeams(ctx, tx, settings.TeamName, settings.FallbackTeams)
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings.SizeTiers != nil {
+		_, err = tx.Exec(ctx, `DELETE FROM team_size_tiers WHERE team_name = $1`, settings.TeamName)
+		if err != nil {
+			repo.Logger.Error("Error delete size tiers", zap.Error(err))
+			return err
+		}
+
+		err = repo.insertSizeTiers(ctx, tx, settings.TeamName, settings.SizeTiers)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertFallbackTeams - записать резервные команды в порядке приоритета
+func (repo *Repository) insertFallbackTeams(ctx context.Context, tx pgx.Tx, teamName string, fallbackTeams []string) error {
+	for i, fallbackName := range fallbackTeams {
+		_, err := tx.Exec(ctx, `INSERT INTO team_fallbacks (team_name, fallback_team_name, position)
+			VALUES ($1, $2, $3)`, teamName, fallbackName, i)
+		if err != nil {
+			repo.Logger.Error("Error insert fallback team", zap.Error(err), zap.String("fallback_team", fallbackName))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insertSizeTiers - сохранить уровни размера команды в порядке списка
+func (repo *Repository) insertSizeTiers(ctx context.Context, tx pgx.Tx, teamName string, tiers []entity.SizeTier) error {
+	for i, tier := range tiers {
+		_, err := tx.Exec(ctx, `INSERT INTO team_size_tiers (team_name, name, min_lines, min_files, reviewers_count, position)
+			VALUES ($1, $2, $3, $4, $5, $6)`, teamName, tier.Name, tier.MinLines, tier.MinFiles, tier.ReviewersCount, i)
+		if err != nil {
+			repo.Logger.Error("Error insert size tier", zap.Error(err), zap.String("size_tier", tier.Name))
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ChangeActivityUser - изменить активность пользователя
 func (repo *Repository) ChangeActivityUser(ctx context.Context, isActive bool, userID string) error {
 	_, err := repo.DB.Exec(ctx, `UPDATE users SET is_active = $1 WHERE user_id = $2`,
@@ -119,7 +323,9 @@ func (repo *Repository) ChangeActivityUser(ctx context.Context, isActive bool, u
 func (repo *Repository) GetReviewFromUser(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
 	var prs []entity.PullRequestShort
 
-	rows, err := repo.DB.Query(ctx, `SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status
+	rows, err := repo.DB.Query(ctx, `SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, r.state,
+			COALESCE(p.repository, ''), COALESCE(p.source_branch, ''), COALESCE(p.target_branch, ''),
+			COALESCE(p.url, ''), p.additions, p.deletions, p.changed_files_count, p.number, COALESCE(p.team_name, '')
 		FROM pr_reviewers r
 		JOIN pr p ON r.pull_request_id = p.pull_request_id
 		WHERE r.user_id = $1`, userID)
@@ -131,7 +337,9 @@ func (repo *Repository) GetReviewFromUser(ctx context.Context, userID string) ([
 
 	for rows.Next() {
 		var pr entity.PullRequestShort
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.ReviewState,
+			&pr.Repository, &pr.SourceBranch, &pr.TargetBranch, &pr.URL, &pr.Additions, &pr.Deletions,
+			&pr.ChangedFilesCount, &pr.Number, &pr.TeamName); err != nil {
 			repo.Logger.Error("Error scanning PR", zap.Error(err))
 			return nil, err
 		}
@@ -141,6 +349,73 @@ func (repo *Repository) GetReviewFromUser(ctx context.Context, userID string) ([
 	return prs, nil
 }
 
+// GetTeamOpenReviewCounts - количество OPEN pr на ревью у каждого участника команды
+func (repo *Repository) GetTeamOpenReviewCounts(ctx context.Context, teamName string) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	rows, err := repo.DB.Query(ctx, `SELECT tm.user_id, COUNT(p.pull_request_id)
+		FROM team_members tm
+		LEFT JOIN pr_reviewers r ON r.user_id = tm.user_id
+		LEFT JOIN pr p ON p.pull_request_id = r.pull_request_id AND p.status = 'OPEN'
+		WHERE tm.team_name = $1
+		GROUP BY tm.user_id`, teamName)
+	if err != nil {
+		repo.Logger.Error("Error selecting open review counts", zap.Error(err), zap.String("team_name", teamName))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			repo.Logger.Error("Error scanning open review count", zap.Error(err))
+			return nil, err
+		}
+		counts[userID] = count
+	}
+
+	if rows.Err() != nil {
+		repo.Logger.Error("Error selecting open review counts", zap.Error(rows.Err()))
+		return nil, rows.Err()
+	}
+
+	return counts, nil
+}
+
+// GetOpenReviewCounts - количество OPEN pr на ревью у каждого из указанных пользователей
+func (repo *Repository) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	rows, err := repo.DB.Query(ctx, `SELECT r.user_id, COUNT(*)
+		FROM pr_reviewers r
+		JOIN pr p ON p.pull_request_id = r.pull_request_id
+		WHERE r.user_id = ANY($1) AND p.status = 'OPEN'
+		GROUP BY r.user_id`, userIDs)
+	if err != nil {
+		repo.Logger.Error("Error selecting open review counts", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			repo.Logger.Error("Error scanning open review count", zap.Error(err))
+			return nil, err
+		}
+		counts[userID] = count
+	}
+
+	if rows.Err() != nil {
+		repo.Logger.Error("Error selecting open review counts", zap.Error(rows.Err()))
+		return nil, rows.Err()
+	}
+
+	return counts, nil
+}
+
 // CreatePullRequest - создать новый pr
 func (repo *Repository) CreatePullRequest(ctx context.Context, pr entity.PullRequest) error {
 	tx, err := repo.DB.Begin(ctx)
@@ -162,10 +437,26 @@ func (repo *Repository) CreatePullRequest(ctx context.Context, pr entity.PullReq
 		}
 	}()
 
+	// репозиторий, впервые встреченный в pr, регистрируется без команд
+	if pr.Repository != "" {
+		_, err = tx.Exec(ctx, `INSERT INTO repositories (repository_name) VALUES ($1) ON CONFLICT DO NOTHING`,
+			pr.Repository)
+		if err != nil {
+			repo.Logger.Error("CreatePullRequest: Failed to insert repository", zap.Error(err))
+			return err
+		}
+	}
+
 	_, err = tx.Exec(ctx, `
-        INSERT INTO pr (pull_request_id, pull_request_name, author_id, status, created_at)
-        VALUES ($1, $2, $3, $4, $5)`,
-		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.CreatedAt)
+        INSERT INTO pr (pull_request_id, pull_request_name, author_id, status, created_at, reviewers_count,
+            repository, source_branch, target_branch, url, additions, deletions, changed_files_count, size_tier,
+            number, team_name)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0),
+            NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), $11, $12, $13, NULLIF($14, ''), $15,
+            NULLIF($16, ''))`,
+		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.CreatedAt, pr.ReviewersCount,
+		pr.Repository, pr.SourceBranch, pr.TargetBranch, pr.URL, pr.Additions, pr.Deletions, pr.ChangedFilesCount,
+		pr.SizeTier, pr.Number, pr.TeamName)
 	if err != nil {
 		repo.Logger.Error("CreatePullRequest: Failed to insert PR", zap.Error(err))
 		return err
@@ -173,15 +464,34 @@ func (repo *Repository) CreatePullRequest(ctx context.Context, pr entity.PullReq
 
 	for _, reviewerID := range pr.AssignedReviewers {
 		_, err = tx.Exec(ctx, `
-            INSERT INTO pr_reviewers (pull_request_id, user_id)
-            VALUES ($1, $2)`,
-			pr.PullRequestID, reviewerID)
+            INSERT INTO pr_reviewers (pull_request_id, user_id, assignment_reason)
+            VALUES ($1, $2, $3)`,
+			pr.PullRequestID, reviewerID, reasonFor(pr.AssignmentReason, reviewerID))
 		if err != nil {
 			repo.Logger.Error("CreatePullRequest: Failed to insert reviewer", zap.Error(err), zap.String("reviewer_id", reviewerID))
 			return err
 		}
 	}
 
+	for _, label := range pr.Labels {
+		_, err = tx.Exec(ctx, `INSERT INTO pr_labels (pull_request_id, label) VALUES ($1, $2)`,
+			pr.PullRequestID, label)
+		if err != nil {
+			repo.Logger.Error("CreatePullRequest: Failed to insert label", zap.Error(err), zap.String("label", label))
+			return err
+		}
+	}
+
+	if len(pr.ChangedFiles) > 0 {
+		_, err = tx.Exec(ctx, `INSERT INTO pr_files (pull_request_id, path)
+			SELECT $1, UNNEST($2::TEXT[])
+			ON CONFLICT DO NOTHING`, pr.PullRequestID, pr.ChangedFiles)
+		if err != nil {
+			repo.Logger.Error("CreatePullRequest: Failed to insert changed files", zap.Error(err))
+			return err
+		}
+	}
+
 	repo.Logger.Info("Pull request created", zap.String("pr_id", pr.PullRequestID))
 
 	return nil
@@ -192,19 +502,24 @@ func (repo *Repository) GetPR(ctx context.Context, pullRequestID string) (entity
 	var pr entity.PullRequest
 
 	row := repo.DB.QueryRow(ctx, `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at,
+			COALESCE(reviewers_count, 0), COALESCE(repository, ''), COALESCE(source_branch, ''),
+			COALESCE(target_branch, ''), COALESCE(url, ''), additions, deletions, changed_files_count,
+			COALESCE(size_tier, ''), number, COALESCE(team_name, '')
 		FROM pr WHERE pull_request_id = $1`, pullRequestID)
 
 	var mergedAt *time.Time
-	err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt)
+	err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &mergedAt,
+		&pr.ReviewersCount, &pr.Repository, &pr.SourceBranch, &pr.TargetBranch, &pr.URL, &pr.Additions,
+		&pr.Deletions, &pr.ChangedFilesCount, &pr.SizeTier, &pr.Number, &pr.TeamName)
 	if err != nil {
 		repo.Logger.Error("Error selecting PR", zap.Error(err))
 		return pr, entity.ErrNotFound
 	}
 	pr.MergedAt = mergedAt
 
-	rows, err := repo.DB.Query(ctx, `SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1`,
-		pullRequestID)
+	rows, err := repo.DB.Query(ctx, `SELECT user_id, assignment_reason, state FROM pr_reviewers
+		WHERE pull_request_id = $1`, pullRequestID)
 	if err != nil {
 		repo.Logger.Error("Error selecting PR reviewers", zap.Error(err))
 		return pr, err
@@ -212,13 +527,24 @@ func (repo *Repository) GetPR(ctx context.Context, pullRequestID string) (entity
 	defer rows.Close()
 
 	var reviewers []string
+	pr.ReviewStates = make(map[string]string)
 	for rows.Next() {
-		var userID string
-		if err := rows.Scan(&userID); err != nil {
+		var userID, state string
+		var reason *entity.AssignmentReason
+		if err := rows.Scan(&userID, &reason, &state); err != nil {
 			repo.Logger.Error("Error scanning reviewer", zap.Error(err))
 			return pr, err
 		}
 		reviewers = append(reviewers, userID)
+		pr.ReviewStates[userID] = state
+
+		// у ревьюверов, назначенных до появления объяснений, его нет
+		if reason != nil {
+			if pr.AssignmentReason == nil {
+				pr.AssignmentReason = make(map[string]entity.AssignmentReason)
+			}
+			pr.AssignmentReason[userID] = *reason
+		}
 	}
 
 	if rows.Err() != nil {
@@ -228,56 +554,197 @@ func (repo *Repository) GetPR(ctx context.Context, pullRequestID string) (entity
 
 	pr.AssignedReviewers = reviewers
 
+	labelRows, err := repo.DB.Query(ctx, `SELECT label FROM pr_labels WHERE pull_request_id = $1 ORDER BY label`,
+		pullRequestID)
+	if err != nil {
+		repo.Logger.Error("Error selecting PR labels", zap.Error(err))
+		return pr, err
+	}
+	defer labelRows.Close()
+
+	for labelRows.Next() {
+		var label string
+		if err := labelRows.Scan(&label); err != nil {
+			repo.Logger.Error("Error scanning label", zap.Error(err))
+			return pr, err
+		}
+		pr.Labels = append(pr.Labels, label)
+	}
+
+	fileRows, err := repo.DB.Query(ctx, `SELECT path FROM pr_files WHERE pull_request_id = $1 ORDER BY path`,
+		pullRequestID)
+	if err != nil {
+		repo.Logger.Error("Error selecting PR files", zap.Error(err))
+		return pr, err
+	}
+	defer fileRows.Close()
+
+	for fileRows.Next() {
+		var path string
+		if err := fileRows.Scan(&path); err != nil {
+			repo.Logger.Error("Error scanning PR file", zap.Error(err))
+			return pr, err
+		}
+		pr.ChangedFiles = append(pr.ChangedFiles, path)
+	}
+
 	return pr, nil
 }
 
-// UpdatePRStatus - обновить статус pr
-func (repo *Repository) UpdatePRStatus(ctx context.Context, prID, newPrStatus string) error {
-	_, err := repo.DB.Exec(ctx, `UPDATE pr SET status = $1 WHERE pull_request_id = $2`, newPrStatus, prID)
+// ClosePr - закрыть pr, если он все еще в статусе from, и снять с него ревьюверов в одной транзакции
+func (repo *Repository) ClosePr(ctx context.Context, prID string, from entity.PRStatus) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `UPDATE pr SET status = $1 WHERE pull_request_id = $2 AND status = $3`,
+		entity.PRStatusClosed, prID, from)
 	if err != nil {
 		repo.Logger.Error("Error update PR status", zap.Error(err))
 		return err
 	}
 
+	// статус успели изменить параллельно
+	if cmdTag.RowsAffected() == 0 {
+		err = entity.ErrInvalidTransition
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1`, prID)
+	if err != nil {
+		repo.Logger.Error("Error delete PR reviewers", zap.Error(err))
+		return err
+	}
+
+	repo.Logger.Info("PR closed", zap.String("pr_id", prID))
+
 	return nil
 }
 
-// MergePr - меняем статус pr
-func (repo *Repository) MergePr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+// MergePr - меняем статус pr; override записывается в аудит в той же транзакции
+func (repo *Repository) MergePr(ctx context.Context, prID string, override *entity.MergeOverride) (*entity.PullRequest, error) {
 	pr, err := repo.GetPR(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
 
-	if pr.Status == "MERGED" {
+	if pr.Status == entity.PRStatusMerged {
 		return &pr, nil
 	}
 
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
 	now := time.Now()
-	pr.Status = "MERGED"
+	pr.Status = entity.PRStatusMerged
 	pr.MergedAt = &now
 
 	// Обновляем и статус, и merged_at в БД
-	_, err = repo.DB.Exec(ctx, `UPDATE pr SET status = $1, merged_at = $2 WHERE pull_request_id = $3`,
-		pr.Status, now, prID)
+	cmdTag, err := tx.Exec(ctx, `UPDATE pr SET status = $1, merged_at = $2 WHERE pull_request_id = $3 AND status = $4`,
+		pr.Status, now, prID, entity.PRStatusOpen)
 	if err != nil {
 		repo.Logger.Error("Error update PR status and merged_at", zap.Error(err))
 		return nil, err
 	}
 
+	if cmdTag.RowsAffected() == 0 {
+		err = entity.ErrInvalidTransition
+		return nil, err
+	}
+
+	if override != nil {
+		_, err = tx.Exec(ctx, `INSERT INTO merge_overrides (pull_request_id, overridden_by, reason, unmet_conditions,
+			created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			prID, override.OverriddenBy, override.Reason, override.UnmetConditions, now)
+		if err != nil {
+			repo.Logger.Error("Error insert into merge_overrides", zap.Error(err))
+			return nil, err
+		}
+
+		repo.Logger.Warn("PR merged with policy override",
+			zap.String("pr_id", prID),
+			zap.String("overridden_by", override.OverriddenBy),
+		)
+	}
+
 	return &pr, nil
 }
 
+// GetMergeOverrides - записи аудита о мерже в обход политики, prID фильтрует по pr
+func (repo *Repository) GetMergeOverrides(ctx context.Context, prID string) ([]entity.MergeOverride, error) {
+	rows, err := repo.DB.Query(ctx, `SELECT override_id, pull_request_id, overridden_by, reason, unmet_conditions,
+		created_at
+		FROM merge_overrides
+		WHERE $1 = '' OR pull_request_id = $1
+		ORDER BY created_at, override_id`, prID)
+	if err != nil {
+		repo.Logger.Error("Error select merge overrides", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := []entity.MergeOverride{}
+	for rows.Next() {
+		var o entity.MergeOverride
+		if err := rows.Scan(&o.OverrideID, &o.PullRequestID, &o.OverriddenBy, &o.Reason, &o.UnmetConditions,
+			&o.CreatedAt); err != nil {
+			repo.Logger.Error("Error scan merge override", zap.Error(err))
+			return nil, err
+		}
+		overrides = append(overrides, o)
+	}
+
+	return overrides, rows.Err()
+}
+
 // ReassignPrReviewer - переназначить ревьюера
-func (repo *Repository) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (entity.PullRequest, error) {
+func (repo *Repository) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string,
+	reason entity.AssignmentReason) (entity.PullRequest, error) {
 	pr, err := repo.GetPR(ctx, prID)
 	if err != nil {
 		return pr, err
 	}
 
 	// Проверяем, что PR открыт
-	if pr.Status == "MERGED" {
-		return pr, fmt.Errorf("cannot reassign reviewer on merged PR")
+	if pr.Status == entity.PRStatusMerged {
+		return pr, entity.ErrPrMerged
+	}
+	if pr.Status != entity.PRStatusOpen {
+		return pr, fmt.Errorf("%w: cannot reassign reviewer on %s PR", entity.ErrPrNotOpen, pr.Status)
 	}
 
 	// Проверяем, что oldReviewer действительно назначен
@@ -293,8 +760,9 @@ func (repo *Repository) ReassignPrReviewer(ctx context.Context, prID, oldReviewe
 	}
 
 	// Переназначаем в таблице pr_reviewers
-	cmdTag, err := repo.DB.Exec(ctx, `UPDATE pr_reviewers SET user_id = $1 WHERE pull_request_id = $2
-        AND user_id = $3`, newReviewerID, prID, oldReviewerID)
+	cmdTag, err := repo.DB.Exec(ctx, `UPDATE pr_reviewers SET user_id = $1, assignment_reason = $4,
+            state = 'PENDING', submitted_at = NULL
+        WHERE pull_request_id = $2 AND user_id = $3`, newReviewerID, prID, oldReviewerID, reason)
 	if err != nil {
 		repo.Logger.Error("Error reassign PR reviewer", zap.Error(err))
 		return pr, err
@@ -312,6 +780,14 @@ func (repo *Repository) ReassignPrReviewer(ctx context.Context, prID, oldReviewe
 		}
 	}
 
+	if pr.AssignmentReason == nil {
+		pr.AssignmentReason = make(map[string]entity.AssignmentReason)
+	}
+	delete(pr.AssignmentReason, oldReviewerID)
+	pr.AssignmentReason[newReviewerID] = reason
+	delete(pr.ReviewStates, oldReviewerID)
+	pr.ReviewStates[newReviewerID] = entity.ReviewStatePending
+
 	repo.Logger.Info("PR reviewer reassigned",
 		zap.String("pr_id", prID),
 		zap.String("old_reviewer", oldReviewerID),
@@ -321,11 +797,594 @@ func (repo *Repository) ReassignPrReviewer(ctx context.Context, prID, oldReviewe
 	return pr, nil
 }
 
+// GetUnderstaffedPRs - OPEN pr команды, у которых ревьюверов меньше нужного количества
+func (repo *Repository) GetUnderstaffedPRs(ctx context.Context, teamName string) ([]string, error) {
+	rows, err := repo.DB.Query(ctx, `SELECT p.pull_request_id
+		FROM pr p
+		JOIN team t ON t.team_name = p.team_name
+		WHERE p.team_name = $1 AND p.status = 'OPEN'
+			AND (SELECT COUNT(*) FROM pr_reviewers r WHERE r.pull_request_id = p.pull_request_id)
+				< COALESCE(p.reviewers_count, t.reviewers_count)
+		ORDER BY p.created_at, p.pull_request_id`, teamName)
+	if err != nil {
+		repo.Logger.Error("Error selecting understaffed PRs", zap.Error(err), zap.String("team_name", teamName))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prIDs []string
+	for rows.Next() {
+		var prID string
+		if err := rows.Scan(&prID); err != nil {
+			repo.Logger.Error("Error scanning PR", zap.Error(err))
+			return nil, err
+		}
+		prIDs = append(prIDs, prID)
+	}
+
+	return prIDs, rows.Err()
+}
+
+// AddPrReviewers - добавить ревьюверов на pr вместе с объяснением выбора
+func (repo *Repository) AddPrReviewers(ctx context.Context, prID string, reviewerIDs []string,
+	reasons map[string]entity.AssignmentReason) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	err = repo.insertPrReviewers(ctx, tx, prID, reviewerIDs, reasons)
+	if err != nil {
+		return err
+	}
+
+	repo.Logger.Info("PR reviewers added", zap.String("pr_id", prID), zap.Strings("reviewers", reviewerIDs))
+
+	return nil
+}
+
+// OpenPr - перевести pr из статуса from в OPEN и добавить ревьюверов одной транзакцией
+func (repo *Repository) OpenPr(ctx context.Context, prID string, from entity.PRStatus, reviewerIDs []string,
+	reasons map[string]entity.AssignmentReason) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `UPDATE pr SET status = $1 WHERE pull_request_id = $2 AND status = $3`,
+		entity.PRStatusOpen, prID, from)
+	if err != nil {
+		repo.Logger.Error("Error update PR status", zap.Error(err))
+		return err
+	}
+
+	// статус успели изменить параллельно
+	if cmdTag.RowsAffected() == 0 {
+		err = entity.ErrInvalidTransition
+		return err
+	}
+
+	err = repo.insertPrReviewers(ctx, tx, prID, reviewerIDs, reasons)
+	if err != nil {
+		return err
+	}
+
+	repo.Logger.Info("PR opened", zap.String("pr_id", prID), zap.Strings("reviewers", reviewerIDs))
+
+	return nil
+}
+
+// insertPrReviewers - добавить ревьюверов на pr в транзакции tx
+func (repo *Repository) insertPrReviewers(ctx context.Context, tx pgx.Tx, prID string, reviewerIDs []string,
+	reasons map[string]entity.AssignmentReason) error {
+	for _, reviewerID := range reviewerIDs {
+		_, err := tx.Exec(ctx, `INSERT INTO pr_reviewers (pull_request_id, user_id, assignment_reason)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`, prID, reviewerID, reasonFor(reasons, reviewerID))
+		if err != nil {
+			repo.Logger.Error("Error insert into pr_reviewers", zap.Error(err), zap.String("pr_id", prID))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemovePrReviewer - снять ревьювера с pr
+func (repo *Repository) RemovePrReviewer(ctx context.Context, prID, reviewerID string) error {
+	cmdTag, err := repo.DB.Exec(ctx, `DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2`,
+		prID, reviewerID)
+	if err != nil {
+		repo.Logger.Error("Error delete from pr_reviewers", zap.Error(err), zap.String("pr_id", prID))
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotAssigned
+	}
+
+	repo.Logger.Info("PR reviewer removed", zap.String("pr_id", prID), zap.String("reviewer_id", reviewerID))
+
+	return nil
+}
+
+// SetReviewState - изменить состояние ревью ревьювера
+func (repo *Repository) SetReviewState(ctx context.Context, prID, reviewerID, state string) error {
+	cmdTag, err := repo.DB.Exec(ctx, `UPDATE pr_reviewers SET state = $3, submitted_at = NOW()
+		WHERE pull_request_id = $1 AND user_id = $2`, prID, reviewerID, state)
+	if err != nil {
+		repo.Logger.Error("Error update review state", zap.Error(err), zap.String("pr_id", prID))
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotAssigned
+	}
+
+	return nil
+}
+
+// reasonFor - объяснение выбора ревьювера, nil если его нет
+func reasonFor(reasons map[string]entity.AssignmentReason, reviewerID string) *entity.AssignmentReason {
+	reason, ok := reasons[reviewerID]
+	if !ok {
+		return nil
+	}
+
+	return &reason
+}
+
+// GetTeamsByFallback - команды, у которых fallbackTeamName в списке резервных
+func (repo *Repository) GetTeamsByFallback(ctx context.Context, fallbackTeamName string) ([]string, error) {
+	rows, err := repo.DB.Query(ctx, `SELECT team_name FROM team_fallbacks
+		WHERE fallback_team_name = $1 ORDER BY team_name`, fallbackTeamName)
+	if err != nil {
+		repo.Logger.Error("Error selecting dependent teams", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []string
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			repo.Logger.Error("Error scanning team", zap.Error(err))
+			return nil, err
+		}
+		teams = append(teams, teamName)
+	}
+
+	return teams, rows.Err()
+}
+
+// GetPRIDByNumber - pull_request_id pr с номером number в репозитории
+func (repo *Repository) GetPRIDByNumber(ctx context.Context, repository string, number int) (string, error) {
+	var prID string
+
+	err := repo.DB.QueryRow(ctx, `SELECT pull_request_id FROM pr WHERE repository = $1 AND number = $2`,
+		repository, number).Scan(&prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", entity.ErrNotFound
+		}
+		repo.Logger.Error("Error selecting PR by number", zap.Error(err))
+		return "", err
+	}
+
+	return prID, nil
+}
+
+// SaveRepository - зарегистрировать репозиторий и заменить список его команд
+func (repo *Repository) SaveRepository(ctx context.Context, repository entity.Repository) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `INSERT INTO repositories (repository_name) VALUES ($1) ON CONFLICT DO NOTHING`,
+		repository.RepositoryName)
+	if err != nil {
+		repo.Logger.Error("Error insert repository", zap.Error(err))
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM repository_teams WHERE repository_name = $1`, repository.RepositoryName)
+	if err != nil {
+		repo.Logger.Error("Error delete repository teams", zap.Error(err))
+		return err
+	}
+
+	for _, teamName := range repository.Teams {
+		_, err = tx.Exec(ctx, `INSERT INTO repository_teams (repository_name, team_name) VALUES ($1, $2)`,
+			repository.RepositoryName, teamName)
+		if err != nil {
+			repo.Logger.Error("Error insert repository team", zap.Error(err), zap.String("team_name", teamName))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetRepository - репозиторий и его команды
+func (repo *Repository) GetRepository(ctx context.Context, repositoryName string) (*entity.Repository, error) {
+	var exists int
+
+	err := repo.DB.QueryRow(ctx, `SELECT 1 FROM repositories WHERE repository_name = $1`,
+		repositoryName).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		repo.Logger.Error("Error selecting repository", zap.Error(err))
+		return nil, err
+	}
+
+	rows, err := repo.DB.Query(ctx, `SELECT team_name FROM repository_teams
+		WHERE repository_name = $1 ORDER BY team_name`, repositoryName)
+	if err != nil {
+		repo.Logger.Error("Error selecting repository teams", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	repository := &entity.Repository{RepositoryName: repositoryName, Teams: []string{}}
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			repo.Logger.Error("Error scanning repository team", zap.Error(err))
+			return nil, err
+		}
+		repository.Teams = append(repository.Teams, teamName)
+	}
+
+	return repository, rows.Err()
+}
+
+// AddTeamMember - добавить участника в команду.
+// Данные нового пользователя или пользователя без команд сохраняются, участник других команд сохраняет прежние
+func (repo *Repository) AddTeamMember(ctx context.Context, teamName string, member entity.TeamMember) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	err = repo.saveMemberUser(ctx, tx, member)
+	if err != nil {
+		return err
+	}
+
+	err = repo.insertTeamMember(ctx, tx, teamName, member.UserID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// saveMemberUser - сохранить данные нового пользователя или пользователя без команд в транзакции tx.
+// Участник других команд сохраняет прежние данные, переданные для него игнорируются
+func (repo *Repository) saveMemberUser(ctx context.Context, tx pgx.Tx, member entity.TeamMember) error {
+	cmdTag, err := tx.Exec(ctx, `INSERT INTO users (user_id, username, is_active, seniority, max_open_reviews)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET username = EXCLUDED.username, is_active = EXCLUDED.is_active,
+			seniority = EXCLUDED.seniority, max_open_reviews = EXCLUDED.max_open_reviews
+		WHERE NOT EXISTS (SELECT 1 FROM team_members tm WHERE tm.user_id = EXCLUDED.user_id)`,
+		member.UserID, member.Username, member.IsActive, member.Seniority, member.MaxOpenReviews)
+	if err != nil {
+		repo.Logger.Error("Error insert team member", zap.Error(err))
+		return err
+	}
+
+	if cmdTag.RowsAffected() > 0 {
+		return repo.replaceUserTags(ctx, tx, member.UserID, member.Tags)
+	}
+
+	return nil
+}
+
+// UpdateTeamMember - изменить участника команды, nil поля не меняются
+func (repo *Repository) UpdateTeamMember(ctx context.Context, update entity.TeamMemberUpdate) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	cmdTag, err := tx.Exec(ctx, `UPDATE users
+		SET username = COALESCE($3, username),
+			seniority = COALESCE($4, seniority),
+			max_open_reviews = NULLIF(COALESCE($5, max_open_reviews, 0), 0)
+		WHERE user_id = $1
+			AND EXISTS (SELECT 1 FROM team_members WHERE user_id = $1 AND team_name = $2)`,
+		update.UserID, update.TeamName, update.Username, update.Seniority, update.MaxOpenReviews)
+	if err != nil {
+		repo.Logger.Error("Error update team member", zap.Error(err))
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		err = entity.ErrNotFound
+		return err
+	}
+
+	if update.Tags != nil {
+		err = repo.replaceUserTags(ctx, tx, update.UserID, update.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveTeamMember - убрать участника из команды, передав его открытые ревью по handoffs.
+// Основной становится другая команда пользователя; без команд он остается в истории pr неактивным
+func (repo *Repository) RemoveTeamMember(ctx context.Context, teamName, userID string,
+	handoffs []entity.ReviewHandoff) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	err = repo.handOffReviews(ctx, tx, handoffs)
+	if err != nil {
+		return err
+	}
+
+	err = repo.deleteTeamMember(ctx, tx, teamName, userID)
+	if err != nil {
+		return err
+	}
+
+	// за время подбора замен пользователю могли назначить новое ревью pr команды
+	var openReviews int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM pr_reviewers r
+		JOIN pr p ON p.pull_request_id = r.pull_request_id
+		WHERE r.user_id = $1 AND p.status = 'OPEN'
+			AND (p.team_name = $2 OR NOT EXISTS (SELECT 1 FROM team_members WHERE user_id = $1))`,
+		userID, teamName).Scan(&openReviews)
+	if err != nil {
+		repo.Logger.Error("Error count open reviews", zap.Error(err))
+		return err
+	}
+
+	if openReviews > 0 {
+		err = entity.ErrHasOpenReviews
+		return err
+	}
+
+	return nil
+}
+
+// MoveUserTeam - перевести пользователя из fromTeam (пусто - без команды) в toTeam, передав ревью по handoffs
+func (repo *Repository) MoveUserTeam(ctx context.Context, userID, fromTeam, toTeam string,
+	handoffs []entity.ReviewHandoff) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	err = repo.handOffReviews(ctx, tx, handoffs)
+	if err != nil {
+		return err
+	}
+
+	// команда могла измениться, пока подбирались замены
+	if fromTeam != "" {
+		err = repo.deleteTeamMember(ctx, tx, fromTeam, userID)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotFound) {
+				err = fmt.Errorf("teams of user %s changed concurrently", userID)
+			}
+			return err
+		}
+	}
+
+	err = repo.insertTeamMember(ctx, tx, toTeam, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// insertTeamMember - сделать пользователя участником команды; команда становится основной, если другой нет
+func (repo *Repository) insertTeamMember(ctx context.Context, tx pgx.Tx, teamName, userID string) error {
+	cmdTag, err := tx.Exec(ctx, `INSERT INTO team_members (team_name, user_id, is_primary)
+		VALUES ($1, $2, NOT EXISTS (SELECT 1 FROM team_members WHERE user_id = $2 AND is_primary))
+		ON CONFLICT DO NOTHING`, teamName, userID)
+	if err != nil {
+		repo.Logger.Error("Error insert into team_members", zap.Error(err))
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrUserExists
+	}
+
+	return nil
+}
+
+// deleteTeamMember - убрать пользователя из команды; если она была основной, основной становится следующая по имени
+func (repo *Repository) deleteTeamMember(ctx context.Context, tx pgx.Tx, teamName, userID string) error {
+	var wasPrimary bool
+
+	err := tx.QueryRow(ctx, `DELETE FROM team_members WHERE team_name = $1 AND user_id = $2
+		RETURNING is_primary`, teamName, userID).Scan(&wasPrimary)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.ErrNotFound
+		}
+		repo.Logger.Error("Error delete from team_members", zap.Error(err))
+		return err
+	}
+
+	if !wasPrimary {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE team_members SET is_primary = TRUE
+		WHERE user_id = $1 AND team_name = (SELECT MIN(team_name) FROM team_members WHERE user_id = $1)`, userID)
+	if err != nil {
+		repo.Logger.Error("Error promote primary team", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// handOffReviews - передать открытые ревью новым ревьюверам внутри транзакции
+func (repo *Repository) handOffReviews(ctx context.Context, tx pgx.Tx, handoffs []entity.ReviewHandoff) error {
+	for _, handoff := range handoffs {
+		cmdTag, err := tx.Exec(ctx, `UPDATE pr_reviewers SET user_id = $3, assignment_reason = $4,
+				state = 'PENDING', submitted_at = NULL
+			WHERE pull_request_id = $1 AND user_id = $2
+				AND EXISTS (SELECT 1 FROM pr WHERE pull_request_id = $1 AND status = 'OPEN')`,
+			handoff.PullRequestID, handoff.OldReviewerID, handoff.NewReviewerID, handoff.Reason)
+		if err != nil {
+			repo.Logger.Error("Error hand off review", zap.Error(err), zap.String("pr_id", handoff.PullRequestID))
+			return err
+		}
+
+		if cmdTag.RowsAffected() == 0 {
+			return fmt.Errorf("review of %s on PR %s changed concurrently", handoff.OldReviewerID, handoff.PullRequestID)
+		}
+	}
+
+	return nil
+}
+
+// replaceUserTags - заменить теги пользователя внутри транзакции
+func (repo *Repository) replaceUserTags(ctx context.Context, tx pgx.Tx, userID string, tags []string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM user_tags WHERE user_id = $1`, userID)
+	if err != nil {
+		repo.Logger.Error("Error delete user tags", zap.Error(err))
+		return err
+	}
+
+	for _, tag := range tags {
+		_, err = tx.Exec(ctx, `INSERT INTO user_tags (user_id, tag) VALUES ($1, $2)`, userID, tag)
+		if err != nil {
+			repo.Logger.Error("Error insert into user_tags", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetTeamByUserID - получить имя команды по id пользователя
 func (repo *Repository) GetTeamByUserID(ctx context.Context, userID string) (string, error) {
 	var teamName string
 
-	err := repo.DB.QueryRow(ctx, `SELECT team_name FROM users WHERE user_id=$1`, userID).Scan(&teamName)
+	err := repo.DB.QueryRow(ctx, `SELECT team_name FROM team_members WHERE user_id=$1 AND is_primary`,
+		userID).Scan(&teamName)
 	if err != nil {
 		repo.Logger.Error("Error getting team by user ID", zap.Error(err))
 		return "", entity.ErrNotFound
@@ -334,6 +1393,306 @@ func (repo *Repository) GetTeamByUserID(ctx context.Context, userID string) (str
 	return teamName, nil
 }
 
+// GetTeamsByUserID - все команды пользователя, основная первой
+func (repo *Repository) GetTeamsByUserID(ctx context.Context, userID string) ([]string, error) {
+	rows, err := repo.DB.Query(ctx, `SELECT team_name FROM team_members
+		WHERE user_id = $1 ORDER BY is_primary DESC, team_name`, userID)
+	if err != nil {
+		repo.Logger.Error("Error selecting teams by user ID", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []string
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			repo.Logger.Error("Error scanning team", zap.Error(err))
+			return nil, err
+		}
+		teams = append(teams, teamName)
+	}
+
+	return teams, rows.Err()
+}
+
+// GetUser - получить пользователя
+func (repo *Repository) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	var user entity.User
+
+	err := repo.DB.QueryRow(ctx, `SELECT user_id, username,
+			COALESCE((SELECT team_name FROM team_members WHERE user_id = $1 AND is_primary), ''), is_active, seniority, is_admin
+		FROM users WHERE user_id = $1`,
+		userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Seniority, &user.IsAdmin)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		repo.Logger.Error("Error getting user", zap.Error(err))
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetUserSeniority - изменить уровень пользователя
+func (repo *Repository) SetUserSeniority(ctx context.Context, userID, seniority string) error {
+	_, err := repo.DB.Exec(ctx, `UPDATE users SET seniority = $1 WHERE user_id = $2`, seniority, userID)
+	if err != nil {
+		repo.Logger.Error("Error update user seniority", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// SetUserAdmin - выдать или забрать права администратора
+func (repo *Repository) SetUserAdmin(ctx context.Context, userID string, isAdmin bool) error {
+	_, err := repo.DB.Exec(ctx, `UPDATE users SET is_admin = $1 WHERE user_id = $2`, isAdmin, userID)
+	if err != nil {
+		repo.Logger.Error("Error update user admin", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// SetUserMaxOpenReviews - изменить лимит открытых ревью пользователя, nil снимает лимит
+func (repo *Repository) SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error {
+	_, err := repo.DB.Exec(ctx, `UPDATE users SET max_open_reviews = $1 WHERE user_id = $2`, maxOpenReviews, userID)
+	if err != nil {
+		repo.Logger.Error("Error update user max open reviews", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CreateAbsence - создать период отсутствия
+func (repo *Repository) CreateAbsence(ctx context.Context, absence entity.Absence) (int64, error) {
+	var absenceID int64
+
+	err := repo.DB.QueryRow(ctx, `INSERT INTO user_absences (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4) RETURNING absence_id`,
+		absence.UserID, absence.StartsAt, absence.EndsAt, absence.Reason).Scan(&absenceID)
+	if err != nil {
+		repo.Logger.Error("Error insert absence", zap.Error(err))
+		return 0, err
+	}
+
+	return absenceID, nil
+}
+
+// GetAbsence - получить период отсутствия
+func (repo *Repository) GetAbsence(ctx context.Context, absenceID int64) (*entity.Absence, error) {
+	var absence entity.Absence
+
+	err := repo.DB.QueryRow(ctx, `SELECT absence_id, user_id, starts_at, ends_at, reason
+		FROM user_absences WHERE absence_id = $1`, absenceID).
+		Scan(&absence.AbsenceID, &absence.UserID, &absence.StartsAt, &absence.EndsAt, &absence.Reason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		repo.Logger.Error("Error select absence", zap.Error(err))
+		return nil, err
+	}
+
+	return &absence, nil
+}
+
+// GetUserAbsences - получить все периоды отсутствия пользователя
+func (repo *Repository) GetUserAbsences(ctx context.Context, userID string) ([]entity.Absence, error) {
+	return repo.queryAbsences(ctx, `SELECT absence_id, user_id, starts_at, ends_at, reason
+		FROM user_absences WHERE user_id = $1 ORDER BY starts_at`, userID)
+}
+
+// GetTeamAbsences - периоды отсутствия участников команды, которые заканчиваются после from
+func (repo *Repository) GetTeamAbsences(ctx context.Context, teamName string, from time.Time) ([]entity.Absence, error) {
+	return repo.queryAbsences(ctx, `SELECT a.absence_id, a.user_id, a.starts_at, a.ends_at, a.reason
+		FROM user_absences a
+		JOIN team_members tm ON tm.user_id = a.user_id
+		WHERE tm.team_name = $1 AND a.ends_at > $2
+		ORDER BY a.starts_at`, teamName, from)
+}
+
+// queryAbsences - выполнить запрос и прочитать периоды отсутствия
+func (repo *Repository) queryAbsences(ctx context.Context, query string, args ...any) ([]entity.Absence, error) {
+	absences := []entity.Absence{}
+
+	rows, err := repo.DB.Query(ctx, query, args...)
+	if err != nil {
+		repo.Logger.Error("Error select absences", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var absence entity.Absence
+		if err := rows.Scan(&absence.AbsenceID, &absence.UserID, &absence.StartsAt, &absence.EndsAt,
+			&absence.Reason); err != nil {
+			repo.Logger.Error("Error scan absence", zap.Error(err))
+			return nil, err
+		}
+		absences = append(absences, absence)
+	}
+
+	if rows.Err() != nil {
+		repo.Logger.Error("Error select absences", zap.Error(rows.Err()))
+		return nil, rows.Err()
+	}
+
+	return absences, nil
+}
+
+// UpdateAbsence - изменить период отсутствия
+func (repo *Repository) UpdateAbsence(ctx context.Context, absence entity.Absence) error {
+	cmdTag, err := repo.DB.Exec(ctx, `UPDATE user_absences SET starts_at = $1, ends_at = $2, reason = $3
+		WHERE absence_id = $4`, absence.StartsAt, absence.EndsAt, absence.Reason, absence.AbsenceID)
+	if err != nil {
+		repo.Logger.Error("Error update absence", zap.Error(err))
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteAbsence - удалить период отсутствия
+func (repo *Repository) DeleteAbsence(ctx context.Context, absenceID int64) error {
+	cmdTag, err := repo.DB.Exec(ctx, `DELETE FROM user_absences WHERE absence_id = $1`, absenceID)
+	if err != nil {
+		repo.Logger.Error("Error delete absence", zap.Error(err))
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+
+	return nil
+}
+
+// AddUserTags - добавить теги пользователю, существующие теги пропускаются
+func (repo *Repository) AddUserTags(ctx context.Context, userID string, tags []string) error {
+	_, err := repo.DB.Exec(ctx, `INSERT INTO user_tags (user_id, tag)
+		SELECT $1, UNNEST($2::TEXT[])
+		ON CONFLICT DO NOTHING`, userID, tags)
+	if err != nil {
+		repo.Logger.Error("Error insert user tags", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RemoveUserTags - удалить теги пользователя
+func (repo *Repository) RemoveUserTags(ctx context.Context, userID string, tags []string) error {
+	_, err := repo.DB.Exec(ctx, `DELETE FROM user_tags WHERE user_id = $1 AND tag = ANY($2)`, userID, tags)
+	if err != nil {
+		repo.Logger.Error("Error delete user tags", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetUserTags - получить теги пользователя
+func (repo *Repository) GetUserTags(ctx context.Context, userID string) ([]string, error) {
+	tags := []string{}
+
+	rows, err := repo.DB.Query(ctx, `SELECT tag FROM user_tags WHERE user_id = $1 ORDER BY tag`, userID)
+	if err != nil {
+		repo.Logger.Error("Error select user tags", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			repo.Logger.Error("Error scan user tag", zap.Error(err))
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// ReplaceCodeOwnerRules - заменить все правила CODEOWNERS
+func (repo *Repository) ReplaceCodeOwnerRules(ctx context.Context, rules []entity.CodeOwnerRule) error {
+	tx, err := repo.DB.Begin(ctx)
+	if err != nil {
+		repo.Logger.Error("Error begin transaction", zap.Error(err))
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil {
+				repo.Logger.Error("Error rollback", zap.Error(rbErr))
+			}
+			return
+		}
+
+		if cmErr := tx.Commit(ctx); cmErr != nil {
+			repo.Logger.Error("Error commit", zap.Error(cmErr))
+			err = cmErr
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `DELETE FROM code_owner_rules`)
+	if err != nil {
+		repo.Logger.Error("Error delete code owner rules", zap.Error(err))
+		return err
+	}
+
+	for _, rule := range rules {
+		_, err = tx.Exec(ctx, `INSERT INTO code_owner_rules (line, pattern, users, teams) VALUES ($1, $2, $3, $4)`,
+			rule.Line, rule.Pattern, rule.Users, rule.Teams)
+		if err != nil {
+			repo.Logger.Error("Error insert code owner rule", zap.Error(err), zap.String("pattern", rule.Pattern))
+			return err
+		}
+	}
+
+	repo.Logger.Info("Code owner rules replaced", zap.Int("rules", len(rules)))
+
+	return nil
+}
+
+// GetCodeOwnerRules - получить правила CODEOWNERS в порядке файла
+func (repo *Repository) GetCodeOwnerRules(ctx context.Context) ([]entity.CodeOwnerRule, error) {
+	var rules []entity.CodeOwnerRule
+
+	rows, err := repo.DB.Query(ctx, `SELECT line, pattern, users, teams FROM code_owner_rules ORDER BY line`)
+	if err != nil {
+		repo.Logger.Error("Error select code owner rules", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rule entity.CodeOwnerRule
+		if err := rows.Scan(&rule.Line, &rule.Pattern, &rule.Users, &rule.Teams); err != nil {
+			repo.Logger.Error("Error scan code owner rule", zap.Error(err))
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if rows.Err() != nil {
+		repo.Logger.Error("Error select code owner rules", zap.Error(rows.Err()))
+		return nil, rows.Err()
+	}
+
+	return rules, nil
+}
+
 // CheckTeam - проверка на существование команды
 func (repo *Repository) CheckTeam(ctx context.Context, teamName string) (bool, error) {
 	var exists int
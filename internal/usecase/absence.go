@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+	"time"
+)
+
+// AddAbsence - зарегистрировать период отсутствия пользователя
+func (uc *UseCase) AddAbsence(ctx context.Context, absence entity.Absence) (*entity.Absence, error) {
+	if absence.UserID == "" {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, entity.ErrInvalidAbsence
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, absence.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	absenceID, err := uc.repo.CreateAbsence(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+	absence.AbsenceID = absenceID
+
+	return &absence, nil
+}
+
+// GetUserAbsences - получить периоды отсутствия пользователя
+func (uc *UseCase) GetUserAbsences(ctx context.Context, userID string) ([]entity.Absence, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	return uc.repo.GetUserAbsences(ctx, userID)
+}
+
+// UpdateAbsence - изменить период отсутствия
+func (uc *UseCase) UpdateAbsence(ctx context.Context, absence entity.Absence) (*entity.Absence, error) {
+	if absence.AbsenceID == 0 {
+		return nil, fmt.Errorf("absenceID is empty")
+	}
+
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, entity.ErrInvalidAbsence
+	}
+
+	current, err := uc.repo.GetAbsence(ctx, absence.AbsenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// период нельзя передать другому пользователю
+	absence.UserID = current.UserID
+
+	err = uc.repo.UpdateAbsence(ctx, absence)
+	if err != nil {
+		return nil, err
+	}
+
+	return &absence, nil
+}
+
+// DeleteAbsence - удалить период отсутствия
+func (uc *UseCase) DeleteAbsence(ctx context.Context, absenceID int64) error {
+	if absenceID == 0 {
+		return fmt.Errorf("absenceID is empty")
+	}
+
+	return uc.repo.DeleteAbsence(ctx, absenceID)
+}
+
+// GetTeamAbsences - текущие и предстоящие периоды отсутствия участников команды
+func (uc *UseCase) GetTeamAbsences(ctx context.Context, teamName string) ([]entity.Absence, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team name is empty")
+	}
+
+	existTeam, err := uc.repo.CheckTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existTeam {
+		return nil, entity.ErrNotFound
+	}
+
+	return uc.repo.GetTeamAbsences(ctx, teamName, time.Now())
+}
@@ -0,0 +1,563 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+)
+
+// assignment - результат подбора ревьюверов
+type assignment struct {
+	reviewers []string
+	fallback  map[string]string                  // ревьювер -> резервная команда, из которой он выбран
+	reasons   map[string]entity.AssignmentReason // ревьювер -> почему он выбран
+	excluded  map[string]string                  // участник пула -> почему он не может быть выбран
+	seed      int64                              // сид случайности, по которому подбор можно повторить
+	rand      *rand.Rand
+}
+
+// assignRequest - условия подбора ревьюверов
+type assignRequest struct {
+	count      int      // сколько всего ревьюверов нужно
+	authorID   string   // автор pr
+	exclude    []string // кто не может быть выбран
+	labels     []string // метки pr, кандидаты с такими тегами выбираются в первую очередь
+	onlySenior bool     // выбирать только senior
+}
+
+// newAssignment - пустой результат подбора со своим источником случайности
+func newAssignment(seed int64) *assignment {
+	return &assignment{
+		fallback: make(map[string]string),
+		reasons:  make(map[string]entity.AssignmentReason),
+		excluded: make(map[string]string),
+		seed:     seed,
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// candidateSet - подходящие кандидаты одного пула и причины исключения остальных
+type candidateSet struct {
+	source   string // code_owner / team / fallback
+	teamName string
+	members  []entity.TeamMember
+	excluded map[string]string // user_id -> причина исключения
+}
+
+// generateReviewers - генерация ревьюеров на pr: сначала владельцы изменённых файлов, затем команда pr.
+// Если команда требует senior, он выбирается первым
+func (uc *UseCase) generateReviewers(ctx context.Context, teamName string, pr entity.PullRequestCreate) (*assignment, error) {
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	req := assignRequest{
+		count:    team.ReviewersCount,
+		authorID: pr.AuthorID,
+		exclude:  []string{pr.AuthorID},
+		labels:   pr.Labels,
+	}
+
+	// количество из запроса перекрывает настройку команды
+	if pr.ReviewersCount != nil {
+		req.count = *pr.ReviewersCount
+	}
+
+	owners := &codeOwners{}
+	if len(pr.ChangedFiles) > 0 {
+		owners, err = uc.codeOwnersFor(ctx, teamName, pr.ChangedFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// сид из запроса позволяет повторить прошлый подбор
+	seed := uc.seeds.next()
+	if pr.Seed != nil {
+		seed = *pr.Seed
+	}
+
+	result := newAssignment(seed)
+
+	err = uc.fillReviewers(ctx, result, team, owners, req, team.RequireSenior)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// fillReviewers - добрать ревьюверов до req.count: сначала из владельцев изменённых файлов, затем из команды
+// и резервных команд. С seniorFirst сначала добирается один senior, без него подбор невозможен
+func (uc *UseCase) fillReviewers(ctx context.Context, result *assignment, team *entity.Team, owners *codeOwners,
+	req assignRequest, seniorFirst bool) error {
+	if seniorFirst {
+		seniorReq := req
+		seniorReq.count = len(result.reviewers) + 1
+		seniorReq.onlySenior = true
+
+		err := uc.fillFromOwners(ctx, result, team, owners, seniorReq)
+		if err != nil {
+			return err
+		}
+
+		err = uc.fillFromTeams(ctx, result, team, seniorReq)
+		if err != nil {
+			return err
+		}
+
+		if len(result.reviewers) < seniorReq.count {
+			return entity.ErrNoSeniorReviewer
+		}
+	}
+
+	err := uc.fillFromOwners(ctx, result, team, owners, req)
+	if err != nil {
+		return err
+	}
+
+	// правила без совпадений - обычный подбор по команде
+	return uc.fillFromTeams(ctx, result, team, req)
+}
+
+// selectNewReviewer - выбрать нового ревьера по условиям req
+func (uc *UseCase) selectNewReviewer(ctx context.Context, teamName string, req assignRequest) (string,
+	entity.AssignmentReason, error) {
+	team, err := uc.GetTeam(ctx, teamName)
+	if err != nil {
+		return "", entity.AssignmentReason{}, err
+	}
+
+	result := newAssignment(uc.seeds.next())
+
+	err = uc.fillFromTeams(ctx, result, team, req)
+	if err != nil {
+		return "", entity.AssignmentReason{}, err
+	}
+
+	if len(result.reviewers) == 0 {
+		if req.onlySenior {
+			return "", entity.AssignmentReason{}, entity.ErrNoSeniorReviewer
+		}
+		return "", entity.AssignmentReason{}, fmt.Errorf("%w: no active replacement candidate in team %s", entity.ErrNoCandidate, teamName)
+	}
+
+	newReviewerID := result.reviewers[0]
+
+	return newReviewerID, result.reasons[newReviewerID], nil
+}
+
+// checkNewReviewer - проверить ревьювера, выбранного вручную, по тем же условиям, что и при подборе:
+// он должен быть в команде teamName или ее резервных командах
+func (uc *UseCase) checkNewReviewer(ctx context.Context, teamName, reviewerID string,
+	req assignRequest) (entity.AssignmentReason, error) {
+	for _, id := range req.exclude {
+		if id == reviewerID && id != req.authorID {
+			return entity.AssignmentReason{}, entity.ErrAlreadyAssigned
+		}
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, reviewerID)
+	if err != nil {
+		return entity.AssignmentReason{}, err
+	}
+
+	if !existUser {
+		return entity.AssignmentReason{}, entity.ErrNotFound
+	}
+
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return entity.AssignmentReason{}, err
+	}
+
+	for i, name := range append([]string{team.TeamName}, team.FallbackTeams...) {
+		current := team
+		if i > 0 {
+			current, err = uc.repo.GetTeam(ctx, name)
+			if err != nil {
+				return entity.AssignmentReason{}, err
+			}
+		}
+
+		for _, m := range withTeamCapacity(current) {
+			if m.UserID != reviewerID {
+				continue
+			}
+
+			if err := uc.checkCandidate(ctx, m, req); err != nil {
+				return entity.AssignmentReason{}, err
+			}
+
+			source := entity.PoolSourceTeam
+			if i > 0 {
+				source = entity.PoolSourceFallback
+			}
+
+			return entity.AssignmentReason{
+				Strategy:       StrategyManual,
+				Source:         source,
+				TeamName:       name,
+				PoolSize:       1,
+				SeniorRequired: req.onlySenior,
+			}, nil
+		}
+	}
+
+	return entity.AssignmentReason{}, fmt.Errorf("%w: not a member of team %s or its fallback teams",
+		entity.ErrNotCandidate, teamName)
+}
+
+// checkCodeOwner - проверить ревьювера, выбранного вручную, как владельца изменённых файлов;
+// isOwner=false, если он не владеет ни одним из них
+func (uc *UseCase) checkCodeOwner(ctx context.Context, teamName, reviewerID string, changedFiles []string,
+	req assignRequest) (reason entity.AssignmentReason, isOwner bool, err error) {
+	if len(changedFiles) == 0 {
+		return entity.AssignmentReason{}, false, nil
+	}
+
+	owners, err := uc.codeOwnersFor(ctx, teamName, changedFiles)
+	if err != nil {
+		return entity.AssignmentReason{}, false, err
+	}
+
+	idx := slices.IndexFunc(owners.members, func(m entity.TeamMember) bool {
+		return m.UserID == reviewerID
+	})
+	if idx < 0 {
+		return entity.AssignmentReason{}, false, nil
+	}
+
+	if err := uc.checkCandidate(ctx, owners.members[idx], req); err != nil {
+		return entity.AssignmentReason{}, true, err
+	}
+
+	return entity.AssignmentReason{
+		Strategy:       StrategyManual,
+		Source:         entity.PoolSourceCodeOwner,
+		PoolSize:       1,
+		SeniorRequired: req.onlySenior,
+	}, true, nil
+}
+
+// checkCandidate - проверить участника по всем условиям подбора: активность, отсутствие, уровень и лимит открытых ревью
+func (uc *UseCase) checkCandidate(ctx context.Context, m entity.TeamMember, req assignRequest) error {
+	set := candidatesFor([]entity.TeamMember{m}, &assignment{}, req)
+	if reason, ok := set.excluded[m.UserID]; ok {
+		return fmt.Errorf("%w: %s", entity.ErrNotCandidate, reason)
+	}
+
+	if m.MaxOpenReviews != nil {
+		load, err := uc.repo.GetOpenReviewCounts(ctx, []string{m.UserID})
+		if err != nil {
+			return err
+		}
+
+		if len(withinCapacity(set.members, load, set.excluded)) == 0 {
+			return fmt.Errorf("%w: %s", entity.ErrNotCandidate, entity.ExcludedOverCapacity)
+		}
+	}
+
+	return nil
+}
+
+// needsSenior - команда pr требует senior, а среди оставшихся ревьюверов его нет
+func (uc *UseCase) needsSenior(ctx context.Context, teamName string, reviewerIDs []string) (bool, error) {
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return false, err
+	}
+
+	if !team.RequireSenior {
+		return false, nil
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		reviewer, err := uc.repo.GetUser(ctx, reviewerID)
+		if err != nil {
+			return false, err
+		}
+
+		if reviewer.Seniority == entity.SenioritySenior {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// fillFromOwners - добрать ревьюверов до req.count из владельцев изменённых файлов
+func (uc *UseCase) fillFromOwners(ctx context.Context, result *assignment, team *entity.Team, owners *codeOwners,
+	req assignRequest) error {
+	candidates := candidatesFor(owners.members, result, req)
+	candidates.source = entity.PoolSourceCodeOwner
+
+	for _, userID := range owners.unresolved {
+		candidates.excluded[userID] = entity.ExcludedNoTeam
+	}
+
+	picked, err := uc.pickCandidates(team, result, candidates, req,
+		func() (map[string]int, error) {
+			return uc.repo.GetOpenReviewCounts(ctx, memberIDs(candidates.members))
+		})
+	if err != nil {
+		return err
+	}
+
+	result.reviewers = append(result.reviewers, picked...)
+
+	return nil
+}
+
+// fillFromTeams - добрать ревьюверов до req.count из команды, затем из резервных команд по порядку
+func (uc *UseCase) fillFromTeams(ctx context.Context, result *assignment, team *entity.Team, req assignRequest) error {
+	teams := append([]string{team.TeamName}, team.FallbackTeams...)
+
+	for i, teamName := range teams {
+		if len(result.reviewers) >= req.count {
+			break
+		}
+
+		current := team
+		if i > 0 {
+			fallbackTeam, err := uc.repo.GetTeam(ctx, teamName)
+			if err != nil {
+				return err
+			}
+			current = fallbackTeam
+		}
+
+		source := entity.PoolSourceTeam
+		if i > 0 {
+			source = entity.PoolSourceFallback
+		}
+
+		picked, err := uc.pickReviewers(ctx, current, result, req, source)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range picked {
+			result.reviewers = append(result.reviewers, id)
+			if i > 0 {
+				result.fallback[id] = teamName
+			}
+		}
+	}
+
+	return nil
+}
+
+// pickReviewers - выбрать недостающих ревьюверов среди участников команды стратегией команды
+func (uc *UseCase) pickReviewers(ctx context.Context, team *entity.Team, result *assignment, req assignRequest,
+	source string) ([]string, error) {
+	candidates := candidatesFor(withTeamCapacity(team), result, req)
+	candidates.source = source
+	candidates.teamName = team.TeamName
+
+	return uc.pickCandidates(team, result, candidates, req,
+		func() (map[string]int, error) {
+			return uc.repo.GetTeamOpenReviewCounts(ctx, team.TeamName)
+		})
+}
+
+// pickCandidates - выбрать недостающих до req.count кандидатов стратегией команды, сначала среди тех,
+// чьи теги совпадают с метками pr. Кандидаты, достигшие лимита открытых ревью, пропускаются.
+// Нагрузка загружается только если она нужна. Для каждого выбранного запоминается объяснение выбора
+func (uc *UseCase) pickCandidates(team *entity.Team, result *assignment, set candidateSet, req assignRequest,
+	loadFn func() (map[string]int, error)) ([]string, error) {
+	count := req.count - len(result.reviewers)
+
+	// итоговые причины исключения участников пула, в том числе по лимиту, запоминаются в result
+	defer result.noteExclusions(set)
+
+	// если кандидатов нет возвращаем пустой список
+	if len(set.members) == 0 || count <= 0 {
+		return nil, nil
+	}
+
+	strategy := uc.strategyFor(team)
+
+	var load map[string]int
+	if _, ok := strategy.(loadBasedStrategy); ok || hasCapacityLimit(set.members) {
+		var err error
+		load, err = loadFn()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := withinCapacity(set.members, load, set.excluded)
+
+	var picked []string
+	for i, tier := range splitByTags(candidates, req.labels) {
+		if len(picked) >= count {
+			break
+		}
+
+		if len(tier) == 0 {
+			continue
+		}
+
+		pool := CandidatePool{
+			TeamName:   team.TeamName,
+			Candidates: tier,
+			Load:       load,
+			Rand:       result.rand,
+		}
+		tierPicked := strategy.Pick(pool, count-len(picked))
+
+		for _, id := range tierPicked {
+			result.reasons[id] = entity.AssignmentReason{
+				Strategy:       strategy.Name(),
+				Source:         set.source,
+				TeamName:       set.teamName,
+				PoolSize:       len(candidates),
+				MatchedTags:    i == 0,
+				SeniorRequired: req.onlySenior,
+				Exclusions:     set.excluded,
+				Seed:           result.seed,
+			}
+		}
+
+		picked = append(picked, tierPicked...)
+	}
+
+	return picked, nil
+}
+
+// noteExclusions - запомнить, почему участники пула не могут быть выбраны; последняя проверка участника перекрывает прежние
+func (a *assignment) noteExclusions(set candidateSet) {
+	for _, m := range set.members {
+		delete(a.excluded, m.UserID)
+	}
+
+	for id, reason := range set.excluded {
+		a.excluded[id] = reason
+	}
+}
+
+// withTeamCapacity - участники команды, у которых не задан лимит, получают лимит команды по умолчанию
+func withTeamCapacity(team *entity.Team) []entity.TeamMember {
+	members := append([]entity.TeamMember(nil), team.Members...)
+
+	if team.DefaultMaxOpenReviews == nil {
+		return members
+	}
+
+	for i := range members {
+		if members[i].MaxOpenReviews == nil {
+			members[i].MaxOpenReviews = team.DefaultMaxOpenReviews
+		}
+	}
+
+	return members
+}
+
+// hasCapacityLimit - есть ли у кого-то из кандидатов лимит открытых ревью
+func hasCapacityLimit(candidates []entity.TeamMember) bool {
+	for _, m := range candidates {
+		if m.MaxOpenReviews != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withinCapacity - кандидаты, у которых открытых ревью меньше лимита; остальные попадают в excluded
+func withinCapacity(candidates []entity.TeamMember, load map[string]int, excluded map[string]string) []entity.TeamMember {
+	var result []entity.TeamMember
+	for _, m := range candidates {
+		if m.MaxOpenReviews != nil && load[m.UserID] >= *m.MaxOpenReviews {
+			excluded[m.UserID] = entity.ExcludedOverCapacity
+			continue
+		}
+		result = append(result, m)
+	}
+
+	return result
+}
+
+// candidatesFor - активные участники, подходящие под условия req, кроме исключённых и уже выбранных
+func candidatesFor(members []entity.TeamMember, result *assignment, req assignRequest) candidateSet {
+	excludeMap := make(map[string]struct{})
+	for _, id := range req.exclude {
+		excludeMap[id] = struct{}{}
+	}
+	for _, id := range result.reviewers {
+		excludeMap[id] = struct{}{}
+	}
+
+	set := candidateSet{excluded: make(map[string]string)}
+	for _, m := range members {
+		_, alreadyExcluded := excludeMap[m.UserID]
+
+		switch {
+		case m.UserID == req.authorID:
+			set.excluded[m.UserID] = entity.ExcludedAuthor
+		case alreadyExcluded:
+			set.excluded[m.UserID] = entity.ExcludedAssigned
+		// is_active - ручной выключатель, период отсутствия тоже делает участника неактивным
+		case !m.IsActive:
+			set.excluded[m.UserID] = entity.ExcludedInactive
+		case m.IsAway:
+			set.excluded[m.UserID] = entity.ExcludedAway
+		case req.onlySenior && m.Seniority != entity.SenioritySenior:
+			set.excluded[m.UserID] = entity.ExcludedNotSenior
+		default:
+			set.members = append(set.members, m)
+		}
+	}
+
+	return set
+}
+
+// splitByTags - разделить кандидатов на тех, чьи теги пересекаются с метками pr, и остальных
+func splitByTags(candidates []entity.TeamMember, labels []string) [][]string {
+	labelSet := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		labelSet[label] = struct{}{}
+	}
+
+	var matched, rest []string
+	for _, m := range candidates {
+		overlap := false
+		for _, tag := range m.Tags {
+			if _, ok := labelSet[tag]; ok {
+				overlap = true
+				break
+			}
+		}
+
+		if overlap {
+			matched = append(matched, m.UserID)
+		} else {
+			rest = append(rest, m.UserID)
+		}
+	}
+
+	return [][]string{matched, rest}
+}
+
+// memberIDs - id участников
+func memberIDs(members []entity.TeamMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.UserID)
+	}
+
+	return ids
+}
+
+// strategyFor - стратегия команды, либо стратегия из конфига
+func (uc *UseCase) strategyFor(team *entity.Team) AssignmentStrategy {
+	if strategy, ok := uc.strategies[team.AssignmentStrategy]; ok {
+		return strategy
+	}
+
+	return uc.strategies[uc.defaultStrategy]
+}
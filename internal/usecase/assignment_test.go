@@ -0,0 +1,413 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+	"testing"
+)
+
+// testUseCase - бизнес логика поверх repo со стратегией random по умолчанию
+func testUseCase(repo RepositoryProvider) *UseCase {
+	return &UseCase{
+		repo:            repo,
+		strategies:      newStrategies(),
+		defaultStrategy: StrategyRandom,
+		seeds:           newSeedSource(rand.NewSource(testSeed)),
+	}
+}
+
+// activeMembers - активные участники команды с указанными id
+func activeMembers(ids ...string) []entity.TeamMember {
+	members := make([]entity.TeamMember, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, entity.TeamMember{UserID: id, Username: id, IsActive: true})
+	}
+
+	return members
+}
+
+// checkReviewers - ревьюверы различны, не совпадают с автором и входят в allowed
+func checkReviewers(t *testing.T, reviewers []string, author string, allowed ...string) {
+	t.Helper()
+
+	seen := make(map[string]bool, len(reviewers))
+	for _, id := range reviewers {
+		if id == author {
+			t.Errorf("reviewers = %v, author %s assigned", reviewers, author)
+		}
+		if !slices.Contains(allowed, id) {
+			t.Errorf("reviewers = %v, %s is not allowed", reviewers, id)
+		}
+		if seen[id] {
+			t.Errorf("reviewers = %v, %s assigned twice", reviewers, id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestGenerateReviewersCount(t *testing.T) {
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {
+			TeamName:       "backend",
+			ReviewersCount: 2,
+			Members: append(activeMembers("author", "u1", "u2", "u3", "u4"),
+				entity.TeamMember{UserID: "inactive", IsActive: false},
+				entity.TeamMember{UserID: "away", IsActive: true, IsAway: true}),
+		},
+	}}
+
+	count := func(n int) *int { return &n }
+
+	tests := []struct {
+		name           string
+		reviewersCount *int
+		want           int
+	}{
+		{name: "team setting", want: 2},
+		{name: "request overrides team", reviewersCount: count(3), want: 3},
+		{name: "request below team setting", reviewersCount: count(1), want: 1},
+		{name: "more than candidates", reviewersCount: count(10), want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := testUseCase(repo).generateReviewers(context.Background(), "backend", entity.PullRequestCreate{
+				AuthorID:       "author",
+				ReviewersCount: tt.reviewersCount,
+			})
+			if err != nil {
+				t.Fatalf("generateReviewers() error = %v", err)
+			}
+
+			if len(result.reviewers) != tt.want {
+				t.Errorf("reviewers = %v, want %d", result.reviewers, tt.want)
+			}
+			checkReviewers(t, result.reviewers, "author", "u1", "u2", "u3", "u4")
+		})
+	}
+}
+
+func TestGenerateReviewersFallback(t *testing.T) {
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {
+			TeamName:       "backend",
+			ReviewersCount: 3,
+			FallbackTeams:  []string{"platform", "infra"},
+			Members:        append(activeMembers("author", "u1"), entity.TeamMember{UserID: "u2", IsActive: false}),
+		},
+		"platform": {
+			TeamName: "platform",
+			Members:  append(activeMembers("p1"), entity.TeamMember{UserID: "p2", IsActive: false}),
+		},
+		"infra": {
+			TeamName: "infra",
+			Members:  activeMembers("i1", "i2"),
+		},
+	}}
+
+	result, err := testUseCase(repo).generateReviewers(context.Background(), "backend",
+		entity.PullRequestCreate{AuthorID: "author"})
+	if err != nil {
+		t.Fatalf("generateReviewers() error = %v", err)
+	}
+
+	if len(result.reviewers) != 3 {
+		t.Fatalf("reviewers = %v, want 3", result.reviewers)
+	}
+	checkReviewers(t, result.reviewers, "author", "u1", "p1", "i1", "i2")
+
+	// сначала своя команда, затем резервные по порядку и только на недостающие места
+	if result.reviewers[0] != "u1" || result.reviewers[1] != "p1" {
+		t.Errorf("reviewers = %v, want u1 from team, then p1 from platform", result.reviewers)
+	}
+
+	if _, ok := result.fallback["u1"]; ok {
+		t.Errorf("fallback = %v, u1 is from the PR team", result.fallback)
+	}
+	if result.fallback["p1"] != "platform" || result.fallback[result.reviewers[2]] != "infra" {
+		t.Errorf("fallback = %v, want p1 from platform and %s from infra", result.fallback, result.reviewers[2])
+	}
+
+	if reason := result.reasons["u1"]; reason.Source != entity.PoolSourceTeam || reason.TeamName != "backend" {
+		t.Errorf("reason for u1 = %+v, want team source from backend", reason)
+	}
+	if reason := result.reasons["p1"]; reason.Source != entity.PoolSourceFallback || reason.TeamName != "platform" {
+		t.Errorf("reason for p1 = %+v, want fallback source from platform", reason)
+	}
+}
+
+func TestGenerateReviewersFallbackNotNeeded(t *testing.T) {
+	// резервная команда не существует: обращение к ней завершилось бы ошибкой
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {
+			TeamName:       "backend",
+			ReviewersCount: 2,
+			FallbackTeams:  []string{"missing"},
+			Members:        activeMembers("author", "u1", "u2"),
+		},
+	}}
+
+	result, err := testUseCase(repo).generateReviewers(context.Background(), "backend",
+		entity.PullRequestCreate{AuthorID: "author"})
+	if err != nil {
+		t.Fatalf("generateReviewers() error = %v", err)
+	}
+
+	if len(result.reviewers) != 2 {
+		t.Errorf("reviewers = %v, want 2", result.reviewers)
+	}
+	checkReviewers(t, result.reviewers, "author", "u1", "u2")
+
+	if len(result.fallback) != 0 {
+		t.Errorf("fallback = %v, want none", result.fallback)
+	}
+}
+
+func TestGenerateReviewersCodeOwners(t *testing.T) {
+	repo := &fakeRepo{
+		teams: map[string]*entity.Team{
+			"backend": {
+				TeamName:       "backend",
+				ReviewersCount: 2,
+				Members:        activeMembers("author", "u1", "u2", "owner"),
+			},
+		},
+		rules: []entity.CodeOwnerRule{
+			{Pattern: "*.go", Users: []string{"owner", "author"}},
+			{Pattern: "docs/", Users: []string{"u1"}},
+		},
+	}
+
+	result, err := testUseCase(repo).generateReviewers(context.Background(), "backend", entity.PullRequestCreate{
+		AuthorID:     "author",
+		ChangedFiles: []string{"cmd/main.go"},
+	})
+	if err != nil {
+		t.Fatalf("generateReviewers() error = %v", err)
+	}
+
+	// владелец файла назначается первым, остальные места добираются из команды
+	if len(result.reviewers) != 2 || result.reviewers[0] != "owner" {
+		t.Errorf("reviewers = %v, want owner first and 2 in total", result.reviewers)
+	}
+	checkReviewers(t, result.reviewers, "author", "owner", "u1", "u2")
+}
+
+func TestGenerateReviewersPrefersLabels(t *testing.T) {
+	members := activeMembers("author", "u1", "u2", "u3", "u4")
+	members[2].Tags = []string{"sql"}
+	members[4].Tags = []string{"go", "sql"}
+
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {TeamName: "backend", ReviewersCount: 3, Members: members},
+	}}
+
+	uc := testUseCase(repo)
+	for i := 0; i < 20; i++ {
+		result, err := uc.generateReviewers(context.Background(), "backend", entity.PullRequestCreate{
+			AuthorID: "author",
+			Labels:   []string{"sql"},
+		})
+		if err != nil {
+			t.Fatalf("generateReviewers() error = %v", err)
+		}
+
+		// оба эксперта выбираются раньше остальных, третье место - любой из участников
+		if len(result.reviewers) != 3 || !slices.Contains(result.reviewers[:2], "u2") || !slices.Contains(result.reviewers[:2], "u4") {
+			t.Fatalf("reviewers = %v, want u2 and u4 first", result.reviewers)
+		}
+		checkReviewers(t, result.reviewers, "author", "u1", "u2", "u3", "u4")
+	}
+}
+
+func TestGenerateReviewersRequireSenior(t *testing.T) {
+	members := activeMembers("author", "u1", "u2", "u3", "s1")
+	members[4].Seniority = entity.SenioritySenior
+
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {TeamName: "backend", ReviewersCount: 2, RequireSenior: true, Members: members},
+	}}
+
+	uc := testUseCase(repo)
+	for i := 0; i < 20; i++ {
+		result, err := uc.generateReviewers(context.Background(), "backend",
+			entity.PullRequestCreate{AuthorID: "author"})
+		if err != nil {
+			t.Fatalf("generateReviewers() error = %v", err)
+		}
+
+		if len(result.reviewers) != 2 || !slices.Contains(result.reviewers, "s1") {
+			t.Fatalf("reviewers = %v, want 2 including senior s1", result.reviewers)
+		}
+		checkReviewers(t, result.reviewers, "author", "u1", "u2", "u3", "s1")
+	}
+
+	// единственный senior - автор
+	members[0].Seniority = entity.SenioritySenior
+	members[4].Seniority = entity.SeniorityMiddle
+
+	_, err := testUseCase(repo).generateReviewers(context.Background(), "backend",
+		entity.PullRequestCreate{AuthorID: "author"})
+	if !errors.Is(err, entity.ErrNoSeniorReviewer) {
+		t.Errorf("generateReviewers() without senior error = %v, want ErrNoSeniorReviewer", err)
+	}
+}
+
+func TestSelectNewReviewer(t *testing.T) {
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {TeamName: "backend", ReviewersCount: 1, Members: activeMembers("author", "old", "u1")},
+	}}
+
+	uc := testUseCase(repo)
+	newReviewerID, _, err := uc.selectNewReviewer(context.Background(), "backend",
+		assignRequest{count: 1, authorID: "author", exclude: []string{"old"}})
+	if err != nil {
+		t.Fatalf("selectNewReviewer() error = %v", err)
+	}
+	if newReviewerID != "u1" {
+		t.Errorf("selectNewReviewer() = %s, want u1", newReviewerID)
+	}
+
+	// замены нет - все остальные уже исключены
+	_, _, err = uc.selectNewReviewer(context.Background(), "backend",
+		assignRequest{count: 1, authorID: "author", exclude: []string{"old", "u1"}})
+	if !errors.Is(err, entity.ErrNoCandidate) {
+		t.Errorf("selectNewReviewer() without candidates error = %v, want ErrNoCandidate", err)
+	}
+}
+
+func TestWithinCapacity(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	candidates := []entity.TeamMember{
+		{UserID: "unlimited"},
+		{UserID: "below", MaxOpenReviews: limit(2)},
+		{UserID: "at", MaxOpenReviews: limit(2)},
+		{UserID: "over", MaxOpenReviews: limit(1)},
+		{UserID: "no_load", MaxOpenReviews: limit(1)},
+	}
+	load := map[string]int{"unlimited": 10, "below": 1, "at": 2, "over": 3}
+
+	excluded := map[string]string{"author": entity.ExcludedAuthor}
+	got := withinCapacity(candidates, load, excluded)
+
+	if want := []string{"unlimited", "below", "no_load"}; !slices.Equal(memberIDs(got), want) {
+		t.Errorf("withinCapacity() = %v, want %v", memberIDs(got), want)
+	}
+
+	wantExcluded := map[string]string{
+		"author": entity.ExcludedAuthor,
+		"at":     entity.ExcludedOverCapacity,
+		"over":   entity.ExcludedOverCapacity,
+	}
+	if len(excluded) != len(wantExcluded) {
+		t.Errorf("excluded = %v, want %v", excluded, wantExcluded)
+	}
+	for id, reason := range wantExcluded {
+		if excluded[id] != reason {
+			t.Errorf("excluded[%s] = %q, want %q", id, excluded[id], reason)
+		}
+	}
+
+	// без нагрузки лимит не достигнут ни у кого
+	if got := withinCapacity(candidates, nil, map[string]string{}); len(got) != len(candidates) {
+		t.Errorf("withinCapacity() without load = %v, want all candidates", memberIDs(got))
+	}
+}
+
+func TestWithTeamCapacity(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	team := &entity.Team{
+		Members: []entity.TeamMember{
+			{UserID: "own", MaxOpenReviews: limit(5)},
+			{UserID: "default"},
+		},
+	}
+
+	// без лимита команды лимиты участников не меняются
+	members := withTeamCapacity(team)
+	if members[0].MaxOpenReviews == nil || *members[0].MaxOpenReviews != 5 || members[1].MaxOpenReviews != nil {
+		t.Errorf("withTeamCapacity() without team limit changed member limits")
+	}
+
+	// лимит команды получают только участники без своего лимита
+	team.DefaultMaxOpenReviews = limit(2)
+	members = withTeamCapacity(team)
+	if *members[0].MaxOpenReviews != 5 {
+		t.Errorf("own limit = %d, want 5", *members[0].MaxOpenReviews)
+	}
+	if members[1].MaxOpenReviews == nil || *members[1].MaxOpenReviews != 2 {
+		t.Errorf("default limit = %v, want 2", members[1].MaxOpenReviews)
+	}
+
+	// участники самой команды не меняются
+	if team.Members[1].MaxOpenReviews != nil {
+		t.Errorf("withTeamCapacity() modified team members")
+	}
+}
+
+func TestGenerateReviewersSkipsOverCapacity(t *testing.T) {
+	limit := 1
+	repo := &fakeRepo{
+		teams: map[string]*entity.Team{
+			"backend": {
+				TeamName:              "backend",
+				ReviewersCount:        3,
+				DefaultMaxOpenReviews: &limit,
+				Members:               activeMembers("author", "u1", "u2", "u3"),
+			},
+		},
+		load: map[string]int{"u1": 1, "u2": 0, "u3": 0},
+	}
+
+	uc := testUseCase(repo)
+	for i := 0; i < 20; i++ {
+		result, err := uc.generateReviewers(context.Background(), "backend",
+			entity.PullRequestCreate{AuthorID: "author"})
+		if err != nil {
+			t.Fatalf("generateReviewers() error = %v", err)
+		}
+
+		// u1 достиг лимита, поэтому назначаются только двое
+		if len(result.reviewers) != 2 {
+			t.Fatalf("reviewers = %v, want 2", result.reviewers)
+		}
+		checkReviewers(t, result.reviewers, "author", "u2", "u3")
+
+		if result.excluded["u1"] != entity.ExcludedOverCapacity {
+			t.Errorf("excluded[u1] = %q, want %q", result.excluded["u1"], entity.ExcludedOverCapacity)
+		}
+	}
+}
+
+func TestGenerateReviewersSeed(t *testing.T) {
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {TeamName: "backend", ReviewersCount: 2, Members: activeMembers("author", "u1", "u2", "u3", "u4", "u5")},
+	}}
+	uc := testUseCase(repo)
+
+	first, err := uc.generateReviewers(context.Background(), "backend", entity.PullRequestCreate{AuthorID: "author"})
+	if err != nil {
+		t.Fatalf("generateReviewers() error = %v", err)
+	}
+
+	// записанный сид повторяет подбор, даже если источник сидов уже сдвинулся
+	seed := first.seed
+	again, err := uc.generateReviewers(context.Background(), "backend", entity.PullRequestCreate{AuthorID: "author", Seed: &seed})
+	if err != nil {
+		t.Fatalf("generateReviewers() error = %v", err)
+	}
+
+	if again.seed != seed || !slices.Equal(again.reviewers, first.reviewers) {
+		t.Errorf("generateReviewers() with seed %d = %v, want %v", seed, again.reviewers, first.reviewers)
+	}
+	for _, id := range again.reviewers {
+		if again.reasons[id].Seed != seed {
+			t.Errorf("reason for %s has seed %d, want %d", id, again.reasons[id].Seed, seed)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+)
+
+// BackfillTeam - добрать ревьюверов на OPEN pr команды, у которых их меньше нужного количества
+func (uc *UseCase) BackfillTeam(ctx context.Context, teamName string) (*entity.BackfillResult, error) {
+	if teamName == "" {
+		return nil, fmt.Errorf("team name is empty")
+	}
+
+	existTeam, err := uc.repo.CheckTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existTeam {
+		return nil, entity.ErrNotFound
+	}
+
+	return uc.backfillTeam(ctx, teamName)
+}
+
+// backfillForUser - добор после активации пользователя: его команды и команды, для которых они резервные
+func (uc *UseCase) backfillForUser(ctx context.Context, userID string) ([]entity.BackfilledPR, error) {
+	teams, err := uc.repo.GetTeamsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := append([]string{}, teams...)
+	for _, teamName := range teams {
+		dependent, err := uc.repo.GetTeamsByFallback(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range dependent {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var backfilled []entity.BackfilledPR
+	for _, name := range names {
+		result, err := uc.backfillTeam(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		backfilled = append(backfilled, result.Backfilled...)
+	}
+
+	return backfilled, nil
+}
+
+// backfillTeam - добор ревьюверов на OPEN pr команды обычными правилами подбора
+func (uc *UseCase) backfillTeam(ctx context.Context, teamName string) (*entity.BackfillResult, error) {
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	prIDs, err := uc.repo.GetUnderstaffedPRs(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.BackfillResult{
+		TeamName:     teamName,
+		Backfilled:   []entity.BackfilledPR{},
+		Understaffed: []string{},
+		Failed:       []entity.FailedBackfill{},
+	}
+
+	// ошибка по отдельному pr не прерывает добор остальных
+	for _, prID := range prIDs {
+		added, understaffed, err := uc.backfillPR(ctx, team, prID)
+		if err != nil {
+			result.Failed = append(result.Failed, entity.FailedBackfill{
+				PullRequestID: prID,
+				Reason:        err.Error(),
+			})
+			result.Understaffed = append(result.Understaffed, prID)
+			continue
+		}
+
+		if len(added) > 0 {
+			result.Backfilled = append(result.Backfilled, entity.BackfilledPR{
+				PullRequestID:  prID,
+				AddedReviewers: added,
+			})
+		}
+
+		if understaffed {
+			result.Understaffed = append(result.Understaffed, prID)
+		}
+	}
+
+	return result, nil
+}
+
+// backfillPR - добрать и сохранить недостающих ревьюверов одного pr.
+// Возвращает добавленных ревьюверов и не хватает ли их по-прежнему
+func (uc *UseCase) backfillPR(ctx context.Context, team *entity.Team, prID string) ([]string, bool, error) {
+	pr, err := uc.repo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	added, err := uc.backfillReviewers(ctx, team, pr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(added.reviewers) > 0 {
+		err = uc.repo.AddPrReviewers(ctx, prID, added.reviewers, added.reasons)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	understaffed := len(pr.AssignedReviewers)+len(added.reviewers) < targetReviewers(team, pr)
+
+	return added.reviewers, understaffed, nil
+}
+
+// backfillReviewers - подобрать недостающих ревьюверов на pr обычными правилами подбора: владельцы изменённых файлов,
+// затем команда и резервные команды; если команда требует senior, а его нет, он выбирается первым
+func (uc *UseCase) backfillReviewers(ctx context.Context, team *entity.Team, pr entity.PullRequest) (*assignment, error) {
+	result := newAssignment(uc.seeds.next())
+	result.reviewers = append(result.reviewers, pr.AssignedReviewers...)
+
+	req := assignRequest{
+		count:    targetReviewers(team, pr),
+		authorID: pr.AuthorID,
+		exclude:  []string{pr.AuthorID},
+		labels:   pr.Labels,
+	}
+
+	owners := &codeOwners{}
+	if len(pr.ChangedFiles) > 0 {
+		var err error
+		owners, err = uc.codeOwnersFor(ctx, team.TeamName, pr.ChangedFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	onlySenior, err := uc.needsSenior(ctx, team.TeamName, pr.AssignedReviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.fillReviewers(ctx, result, team, owners, req, onlySenior)
+	if err != nil {
+		return nil, err
+	}
+
+	// в результате остаются только добавленные ревьюверы
+	result.reviewers = result.reviewers[len(pr.AssignedReviewers):]
+
+	return result, nil
+}
+
+// targetReviewers - сколько ревьюверов нужно pr: количество из запроса на создание, иначе настройка команды
+func targetReviewers(team *entity.Team, pr entity.PullRequest) int {
+	if pr.ReviewersCount > 0 {
+		return pr.ReviewersCount
+	}
+
+	return team.ReviewersCount
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path"
+	"pr_reviewer_service/internal/entity"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ImportCodeOwners - разобрать файл CODEOWNERS и заменить им текущие правила
+func (uc *UseCase) ImportCodeOwners(ctx context.Context, content string) (*entity.CodeOwnersImport, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, entity.ErrInvalidCodeOwners
+	}
+
+	result := &entity.CodeOwnersImport{}
+	unknown := make(map[string]struct{})
+
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		rule := entity.CodeOwnerRule{
+			Line:    i + 1,
+			Pattern: fields[0],
+			Users:   []string{},
+			Teams:   []string{},
+		}
+
+		for _, owner := range fields[1:] {
+			// всё после # - комментарий
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+
+			kind, id, err := uc.resolveOwner(ctx, owner)
+			if err != nil {
+				return nil, err
+			}
+
+			switch kind {
+			case ownerUser:
+				rule.Users = append(rule.Users, id)
+			case ownerTeam:
+				rule.Teams = append(rule.Teams, id)
+			default:
+				if _, ok := unknown[owner]; !ok {
+					unknown[owner] = struct{}{}
+					result.UnknownOwners = append(result.UnknownOwners, owner)
+				}
+			}
+		}
+
+		result.Rules = append(result.Rules, rule)
+	}
+
+	if err := uc.repo.ReplaceCodeOwnerRules(ctx, result.Rules); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCodeOwners - получить текущие правила владения
+func (uc *UseCase) GetCodeOwners(ctx context.Context) ([]entity.CodeOwnerRule, error) {
+	return uc.repo.GetCodeOwnerRules(ctx)
+}
+
+// Типы владельцев в CODEOWNERS
+const (
+	ownerUnknown = iota
+	ownerUser
+	ownerTeam
+)
+
+// resolveOwner - сопоставить владельца из CODEOWNERS с пользователем или командой сервиса
+func (uc *UseCase) resolveOwner(ctx context.Context, owner string) (int, string, error) {
+	name := strings.TrimPrefix(owner, "@")
+
+	// @org/team - команда, берем имя после последнего слэша
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+
+		exist, err := uc.repo.CheckTeam(ctx, name)
+		if err != nil || !exist {
+			return ownerUnknown, "", err
+		}
+
+		return ownerTeam, name, nil
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, name)
+	if err != nil {
+		return ownerUnknown, "", err
+	}
+
+	if existUser {
+		return ownerUser, name, nil
+	}
+
+	existTeam, err := uc.repo.CheckTeam(ctx, name)
+	if err != nil || !existTeam {
+		return ownerUnknown, "", err
+	}
+
+	return ownerTeam, name, nil
+}
+
+// codeOwners - владельцы изменённых файлов
+type codeOwners struct {
+	members    []entity.TeamMember // владельцы с атрибутами и лимитом из их команды
+	unresolved []string            // владельцы-пользователи, не состоящие ни в одной команде
+}
+
+// codeOwnersFor - владельцы изменённых файлов; для каждого файла действует последнее подходящее правило.
+// Владелец-пользователь берется из команды pr teamName, если состоит в ней, иначе из своей основной команды
+func (uc *UseCase) codeOwnersFor(ctx context.Context, teamName string, changedFiles []string) (*codeOwners, error) {
+	rules, err := uc.repo.GetCodeOwnerRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]struct{})
+	teams := make(map[string]struct{})
+
+	for _, file := range changedFiles {
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !matchCodeOwnersPattern(rules[i].Pattern, file) {
+				continue
+			}
+
+			for _, userID := range rules[i].Users {
+				users[userID] = struct{}{}
+			}
+			for _, ownerTeam := range rules[i].Teams {
+				teams[ownerTeam] = struct{}{}
+			}
+			break
+		}
+	}
+
+	owners := &codeOwners{}
+	seen := make(map[string]struct{})
+
+	for ownerTeam := range teams {
+		team, err := uc.repo.GetTeam(ctx, ownerTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range withTeamCapacity(team) {
+			if _, ok := seen[member.UserID]; !ok {
+				seen[member.UserID] = struct{}{}
+				owners.members = append(owners.members, member)
+			}
+		}
+	}
+
+	for userID := range users {
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+
+		// берем участника из его команды, чтобы получить все его атрибуты
+		memberTeam, err := uc.reviewerTeam(ctx, userID, teamName)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotFound) {
+				owners.unresolved = append(owners.unresolved, userID)
+				continue
+			}
+			return nil, err
+		}
+
+		team, err := uc.repo.GetTeam(ctx, memberTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		idx := slices.IndexFunc(team.Members, func(m entity.TeamMember) bool {
+			return m.UserID == userID
+		})
+		if idx < 0 {
+			owners.unresolved = append(owners.unresolved, userID)
+			continue
+		}
+
+		owners.members = append(owners.members, withTeamCapacity(team)[idx])
+	}
+
+	return owners, nil
+}
+
+// ids - id всех владельцев, в том числе не состоящих в командах
+func (o *codeOwners) ids() []string {
+	return append(memberIDs(o.members), o.unresolved...)
+}
+
+// matchCodeOwnersPattern - проверить путь файла на соответствие шаблону CODEOWNERS (синтаксис gitignore)
+func matchCodeOwnersPattern(pattern, filePath string) bool {
+	filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	// шаблон со слэшем в начале или в середине привязан к корню репозитория
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	if pattern == "" {
+		return false
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		case pattern[i] == '[':
+			class, end, ok := characterClass(pattern, i)
+			if !ok {
+				expr.WriteString(regexp.QuoteMeta("["))
+				continue
+			}
+			expr.WriteString(class)
+			i = end
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	// шаблон, совпавший с каталогом, покрывает всё его содержимое;
+	// dir/* как и на GitHub покрывает только файлы первого уровня
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	matched, err := regexp.MatchString(expr.String(), filePath)
+	return err == nil && matched
+}
+
+// characterClass - перевести класс символов [...] из шаблона, начинающийся с позиции start, в регулярное выражение.
+// Возвращает выражение и позицию закрывающей скобки; ok=false, если класс не закрыт и [ - обычный символ
+func characterClass(pattern string, start int) (class string, end int, ok bool) {
+	i := start + 1
+
+	negate := i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^')
+	if negate {
+		i++
+	}
+
+	var expr strings.Builder
+	expr.WriteString("[")
+	if negate {
+		expr.WriteString("^/")
+	}
+
+	// ] сразу после открывающей скобки - обычный символ класса
+	for first := true; i < len(pattern); i, first = i+1, false {
+		c := pattern[i]
+
+		switch {
+		case c == ']' && !first:
+			expr.WriteString("]")
+			return expr.String(), i, true
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case c == '/':
+			// класс, как и *, не совпадает со слэшем
+		case c == '-':
+			expr.WriteByte(c)
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	return "", start, false
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+	"testing"
+)
+
+func TestMatchCodeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// * и ** покрывают все файлы
+		{pattern: "*", path: "main.go", want: true},
+		{pattern: "*", path: "cmd/app/main.go", want: true},
+		{pattern: "**", path: "cmd/app/main.go", want: true},
+
+		// шаблон без слэша совпадает с именем на любой глубине
+		{pattern: "*.go", path: "main.go", want: true},
+		{pattern: "*.go", path: "cmd/app/main.go", want: true},
+		{pattern: "*.go", path: "main.js", want: false},
+		{pattern: "*.go", path: "main.go.txt", want: false},
+		{pattern: "main.go", path: "cmd/main.go", want: true},
+		{pattern: "Makefile", path: "Makefile", want: true},
+		{pattern: "Makefile", path: "Makefile.old", want: false},
+
+		// точка и другие символы регулярных выражений - обычные символы
+		{pattern: "file.name", path: "file.name", want: true},
+		{pattern: "file.name", path: "filexname", want: false},
+		{pattern: "a+b.txt", path: "a+b.txt", want: true},
+
+		// ? - ровно один символ, кроме слэша
+		{pattern: "a?c.txt", path: "abc.txt", want: true},
+		{pattern: "a?c.txt", path: "ac.txt", want: false},
+		{pattern: "a?c.txt", path: "a/c.txt", want: false},
+
+		// каталог покрывает всё содержимое
+		{pattern: "docs/", path: "docs/readme.md", want: true},
+		{pattern: "docs/", path: "docs/api/v1.md", want: true},
+		{pattern: "docs/", path: "src/docs/readme.md", want: true},
+		{pattern: "docs/", path: "docs", want: false},
+		{pattern: "docs", path: "docs/readme.md", want: true},
+
+		// слэш в начале или в середине привязывает шаблон к корню
+		{pattern: "/docs/", path: "docs/readme.md", want: true},
+		{pattern: "/docs/", path: "src/docs/readme.md", want: false},
+		{pattern: "src/main.go", path: "src/main.go", want: true},
+		{pattern: "src/main.go", path: "lib/src/main.go", want: false},
+		{pattern: "/build/logs/", path: "build/logs/deep/app.log", want: true},
+
+		// dir/* - только файлы первого уровня
+		{pattern: "apps/*", path: "apps/web.js", want: true},
+		{pattern: "apps/*", path: "apps/web/index.js", want: false},
+
+		// **/ - любое количество каталогов, в том числе ни одного
+		{pattern: "**/logs", path: "logs", want: true},
+		{pattern: "**/logs", path: "deploy/logs", want: true},
+		{pattern: "**/logs", path: "deploy/logs/app.log", want: true},
+		{pattern: "**/logs", path: "deploy/logsx", want: false},
+		{pattern: "docs/**/*.md", path: "docs/readme.md", want: true},
+		{pattern: "docs/**/*.md", path: "docs/api/v1/readme.md", want: true},
+		{pattern: "docs/**/*.md", path: "other/docs/readme.md", want: false},
+		{pattern: "docs/**", path: "docs/api/v1.md", want: true},
+		{pattern: "docs/**", path: "src/docs/a.md", want: false},
+
+		// путь файла нормализуется
+		{pattern: "/src/", path: "./src/main.go", want: true},
+		{pattern: "/src/", path: "/src/main.go", want: true},
+		{pattern: "/src/", path: "lib/../src/main.go", want: true},
+
+		// [...] - один символ из класса, [!...] - любой, кроме перечисленных
+		{pattern: "*.[ch]", path: "src/main.c", want: true},
+		{pattern: "*.[ch]", path: "src/main.h", want: true},
+		{pattern: "*.[ch]", path: "src/main.go", want: false},
+		{pattern: "file[0-9].txt", path: "file7.txt", want: true},
+		{pattern: "file[0-9].txt", path: "filex.txt", want: false},
+		{pattern: "[!a]bc", path: "xbc", want: true},
+		{pattern: "[!a]bc", path: "abc", want: false},
+		{pattern: "[^a]bc", path: "abc", want: false},
+		{pattern: "a[!b]c", path: "a/c", want: false},
+		{pattern: "[]a]x", path: "]x", want: true},
+		{pattern: "[.]md", path: "xmd", want: false},
+		{pattern: "[abc", path: "[abc", want: true},
+		{pattern: "[abc", path: "a", want: false},
+
+		// пустой шаблон ничего не покрывает
+		{pattern: "/", path: "main.go", want: false},
+		{pattern: "", path: "main.go", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := matchCodeOwnersPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchCodeOwnersPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCodeOwnersFor(t *testing.T) {
+	repo := &fakeRepo{
+		teams: map[string]*entity.Team{
+			"backend":  {TeamName: "backend", Members: activeMembers("author", "u1", "owner")},
+			"platform": {TeamName: "platform", Members: activeMembers("p1")},
+		},
+		rules: []entity.CodeOwnerRule{
+			{Pattern: "*.go", Users: []string{"owner", "ghost"}, Teams: []string{"platform"}},
+			{Pattern: "docs/", Users: []string{"u1"}},
+		},
+	}
+
+	owners, err := testUseCase(repo).codeOwnersFor(context.Background(), "backend", []string{"cmd/main.go"})
+	if err != nil {
+		t.Fatalf("codeOwnersFor() error = %v", err)
+	}
+
+	members := memberIDs(owners.members)
+	slices.Sort(members)
+	if want := []string{"owner", "p1"}; !slices.Equal(members, want) {
+		t.Errorf("owners = %v, want %v", members, want)
+	}
+
+	// владелец без команды не может быть назначен, но остается владельцем
+	if want := []string{"ghost"}; !slices.Equal(owners.unresolved, want) {
+		t.Errorf("unresolved = %v, want %v", owners.unresolved, want)
+	}
+	if ids := owners.ids(); len(ids) != 3 || !slices.Contains(ids, "ghost") {
+		t.Errorf("ids() = %v, want owner, p1 and ghost", ids)
+	}
+}
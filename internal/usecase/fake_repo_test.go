@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+	"time"
+)
+
+// fakeRepo - репозиторий в памяти для тестов бизнес логики: команды и нагрузка задаются тестом,
+// чтение остального возвращает пустой результат, запись ничего не делает
+type fakeRepo struct {
+	teams  map[string]*entity.Team
+	load   map[string]int // количество OPEN pr на ревью по пользователю
+	rules  []entity.CodeOwnerRule
+	admins map[string]bool
+}
+
+func (r *fakeRepo) CreateTeam(_ context.Context, _ entity.Team) error {
+	return nil
+}
+
+func (r *fakeRepo) GetTeam(_ context.Context, teamName string) (*entity.Team, error) {
+	team, ok := r.teams[teamName]
+	if !ok {
+		return nil, entity.ErrNotFound
+	}
+
+	copied := *team
+	return &copied, nil
+}
+
+func (r *fakeRepo) UpdateTeamSettings(_ context.Context, _ entity.TeamSettings) error {
+	return nil
+}
+
+func (r *fakeRepo) ChangeActivityUser(_ context.Context, _ bool, _ string) error {
+	return nil
+}
+
+func (r *fakeRepo) GetReviewFromUser(_ context.Context, _ string) ([]entity.PullRequestShort, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) GetTeamOpenReviewCounts(_ context.Context, teamName string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if team, ok := r.teams[teamName]; ok {
+		for _, m := range team.Members {
+			counts[m.UserID] = r.load[m.UserID]
+		}
+	}
+
+	return counts, nil
+}
+
+func (r *fakeRepo) GetOpenReviewCounts(_ context.Context, userIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(userIDs))
+	for _, id := range userIDs {
+		counts[id] = r.load[id]
+	}
+
+	return counts, nil
+}
+
+func (r *fakeRepo) CreatePullRequest(_ context.Context, _ entity.PullRequest) error {
+	return nil
+}
+
+func (r *fakeRepo) GetPR(_ context.Context, _ string) (entity.PullRequest, error) {
+	return entity.PullRequest{}, entity.ErrNotFound
+}
+
+func (r *fakeRepo) ClosePr(_ context.Context, _ string, _ entity.PRStatus) error {
+	return nil
+}
+
+func (r *fakeRepo) OpenPr(_ context.Context, _ string, _ entity.PRStatus, _ []string,
+	_ map[string]entity.AssignmentReason) error {
+	return nil
+}
+
+func (r *fakeRepo) MergePr(_ context.Context, _ string, _ *entity.MergeOverride) (*entity.PullRequest, error) {
+	return nil, entity.ErrNotFound
+}
+
+func (r *fakeRepo) GetMergeOverrides(_ context.Context, _ string) ([]entity.MergeOverride, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) ReassignPrReviewer(_ context.Context, _, _, _ string,
+	_ entity.AssignmentReason) (entity.PullRequest, error) {
+	return entity.PullRequest{}, entity.ErrNotFound
+}
+
+func (r *fakeRepo) GetTeamByUserID(_ context.Context, userID string) (string, error) {
+	if team := r.teamOf(userID); team != nil {
+		return team.TeamName, nil
+	}
+
+	return "", entity.ErrNotFound
+}
+
+func (r *fakeRepo) GetTeamsByUserID(_ context.Context, userID string) ([]string, error) {
+	var teams []string
+	for _, name := range r.teamNames() {
+		if slices.ContainsFunc(r.teams[name].Members, func(m entity.TeamMember) bool { return m.UserID == userID }) {
+			teams = append(teams, name)
+		}
+	}
+
+	return teams, nil
+}
+
+func (r *fakeRepo) GetUser(_ context.Context, userID string) (*entity.User, error) {
+	team := r.teamOf(userID)
+	if team == nil {
+		return nil, entity.ErrNotFound
+	}
+
+	for _, m := range team.Members {
+		if m.UserID == userID {
+			return &entity.User{
+				UserID:    m.UserID,
+				Username:  m.Username,
+				TeamName:  team.TeamName,
+				IsActive:  m.IsActive,
+				Seniority: m.Seniority,
+				IsAdmin:   r.admins[m.UserID],
+			}, nil
+		}
+	}
+
+	return nil, entity.ErrNotFound
+}
+
+func (r *fakeRepo) SetUserSeniority(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (r *fakeRepo) SetUserAdmin(_ context.Context, _ string, _ bool) error {
+	return nil
+}
+
+func (r *fakeRepo) SetUserMaxOpenReviews(_ context.Context, _ string, _ *int) error {
+	return nil
+}
+
+func (r *fakeRepo) CreateAbsence(_ context.Context, _ entity.Absence) (int64, error) {
+	return 1, nil
+}
+
+func (r *fakeRepo) GetAbsence(_ context.Context, _ int64) (*entity.Absence, error) {
+	return nil, entity.ErrNotFound
+}
+
+func (r *fakeRepo) GetUserAbsences(_ context.Context, _ string) ([]entity.Absence, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) UpdateAbsence(_ context.Context, _ entity.Absence) error {
+	return nil
+}
+
+func (r *fakeRepo) DeleteAbsence(_ context.Context, _ int64) error {
+	return nil
+}
+
+func (r *fakeRepo) GetTeamAbsences(_ context.Context, _ string, _ time.Time) ([]entity.Absence, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) AddUserTags(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+
+func (r *fakeRepo) RemoveUserTags(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+
+func (r *fakeRepo) GetUserTags(_ context.Context, userID string) ([]string, error) {
+	if team := r.teamOf(userID); team != nil {
+		for _, m := range team.Members {
+			if m.UserID == userID {
+				return m.Tags, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeRepo) ReplaceCodeOwnerRules(_ context.Context, rules []entity.CodeOwnerRule) error {
+	r.rules = rules
+	return nil
+}
+
+func (r *fakeRepo) GetCodeOwnerRules(_ context.Context) ([]entity.CodeOwnerRule, error) {
+	return r.rules, nil
+}
+
+func (r *fakeRepo) CheckTeam(_ context.Context, teamName string) (bool, error) {
+	_, ok := r.teams[teamName]
+	return ok, nil
+}
+
+func (r *fakeRepo) CheckUser(_ context.Context, userID string) (bool, error) {
+	return r.teamOf(userID) != nil, nil
+}
+
+func (r *fakeRepo) CheckPR(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeRepo) GetUnderstaffedPRs(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func (r *fakeRepo) AddPrReviewers(_ context.Context, _ string, _ []string, _ map[string]entity.AssignmentReason) error {
+	return nil
+}
+
+func (r *fakeRepo) GetTeamsByFallback(_ context.Context, fallbackTeamName string) ([]string, error) {
+	var teams []string
+	for _, team := range r.teams {
+		if slices.Contains(team.FallbackTeams, fallbackTeamName) {
+			teams = append(teams, team.TeamName)
+		}
+	}
+
+	return teams, nil
+}
+
+func (r *fakeRepo) RemovePrReviewer(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (r *fakeRepo) SetReviewState(_ context.Context, _, _, _ string) error {
+	return nil
+}
+
+func (r *fakeRepo) GetPRIDByNumber(_ context.Context, _ string, _ int) (string, error) {
+	return "", entity.ErrNotFound
+}
+
+func (r *fakeRepo) SaveRepository(_ context.Context, _ entity.Repository) error {
+	return nil
+}
+
+func (r *fakeRepo) GetRepository(_ context.Context, _ string) (*entity.Repository, error) {
+	return nil, entity.ErrNotFound
+}
+
+func (r *fakeRepo) AddTeamMember(_ context.Context, _ string, _ entity.TeamMember) error {
+	return nil
+}
+
+func (r *fakeRepo) UpdateTeamMember(_ context.Context, _ entity.TeamMemberUpdate) error {
+	return nil
+}
+
+func (r *fakeRepo) RemoveTeamMember(_ context.Context, _, _ string, _ []entity.ReviewHandoff) error {
+	return nil
+}
+
+func (r *fakeRepo) MoveUserTeam(_ context.Context, _, _, _ string, _ []entity.ReviewHandoff) error {
+	return nil
+}
+
+// teamOf - первая по имени команда, в которой состоит пользователь
+func (r *fakeRepo) teamOf(userID string) *entity.Team {
+	for _, name := range r.teamNames() {
+		for _, m := range r.teams[name].Members {
+			if m.UserID == userID {
+				return r.teams[name]
+			}
+		}
+	}
+
+	return nil
+}
+
+// teamNames - имена команд по алфавиту
+func (r *fakeRepo) teamNames() []string {
+	names := make([]string, 0, len(r.teams))
+	for name := range r.teams {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
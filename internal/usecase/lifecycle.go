@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+)
+
+// MarkReady - черновик готов к ревью: pr открывается и получает ревьюверов по обычным правилам
+func (uc *UseCase) MarkReady(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	pr, err := uc.prForTransition(ctx, prID, entity.PRStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status != entity.PRStatusDraft {
+		return nil, fmt.Errorf("%w: %s is not a draft", entity.ErrInvalidTransition, pr.Status)
+	}
+
+	return uc.openPr(ctx, pr)
+}
+
+// ClosePr - закрыть черновик или открытый pr без мержа; ревьюверы снимаются с pr и освобождаются
+func (uc *UseCase) ClosePr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	pr, err := uc.prForTransition(ctx, prID, entity.PRStatusClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.repo.ClosePr(ctx, prID, pr.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetPullRequest(ctx, prID)
+}
+
+// ReopenPr - переоткрыть закрытый pr; ревьюверы подбираются заново, как при создании
+func (uc *UseCase) ReopenPr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	pr, err := uc.prForTransition(ctx, prID, entity.PRStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	if pr.Status != entity.PRStatusClosed {
+		return nil, fmt.Errorf("%w: %s is not closed", entity.ErrInvalidTransition, pr.Status)
+	}
+
+	return uc.openPr(ctx, pr)
+}
+
+// prForTransition - pr, который можно перевести в статус to
+func (uc *UseCase) prForTransition(ctx context.Context, prID string, to entity.PRStatus) (*entity.PullRequest, error) {
+	pr, err := uc.GetPullRequest(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pr.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s -> %s", entity.ErrInvalidTransition, pr.Status, to)
+	}
+
+	return pr, nil
+}
+
+// openPr - перевести pr в OPEN и назначить ревьюверов: без ревьюверов - как при создании, иначе добрать недостающих
+func (uc *UseCase) openPr(ctx context.Context, pr *entity.PullRequest) (*entity.PullRequest, error) {
+	teamName, err := uc.prTeam(ctx, *pr)
+	if err != nil {
+		return nil, err
+	}
+
+	var assigned *assignment
+	if len(pr.AssignedReviewers) == 0 {
+		create := entity.PullRequestCreate{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			ChangedFiles:    pr.ChangedFiles,
+			Labels:          pr.Labels,
+			TeamName:        pr.TeamName,
+			PRMetadata:      pr.PRMetadata,
+		}
+		if pr.ReviewersCount > 0 {
+			create.ReviewersCount = &pr.ReviewersCount
+		}
+
+		assigned, err = uc.generateReviewers(ctx, teamName, create)
+	} else {
+		var team *entity.Team
+		team, err = uc.repo.GetTeam(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		assigned, err = uc.backfillReviewers(ctx, team, *pr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// ревьюверы подобраны до смены статуса, чтобы при ошибке подбора pr остался в прежнем статусе
+	err = uc.repo.OpenPr(ctx, pr.PullRequestID, pr.Status, assigned.reviewers, assigned.reasons)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetPullRequest(ctx, pr.PullRequestID)
+}
@@ -0,0 +1,405 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+	"strings"
+)
+
+// AddTeamMember - добавить участника в существующую команду.
+// Активному участнику сразу добираются ревью на pr, которым не хватает ревьюверов
+func (uc *UseCase) AddTeamMember(ctx context.Context, add entity.TeamMemberAdd) (*entity.TeamMemberResult, error) {
+	if add.TeamName == "" || add.UserID == "" {
+		return nil, fmt.Errorf("team name or user id is empty")
+	}
+
+	if err := normalizeMember(&add.TeamMember); err != nil {
+		return nil, err
+	}
+
+	existTeam, err := uc.repo.CheckTeam(ctx, add.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existTeam {
+		return nil, entity.ErrNotFound
+	}
+
+	err = uc.repo.AddTeamMember(ctx, add.TeamName, add.TeamMember)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := uc.repo.GetTeam(ctx, add.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// участник уже добавлен: при ошибке добора результат возвращается вместе с ошибкой
+	result := &entity.TeamMemberResult{Team: team}
+	if add.IsActive {
+		result.Backfilled, err = uc.backfillForUser(ctx, add.UserID)
+		if err != nil {
+			return result, fmt.Errorf("member added, backfill failed: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateTeamMember - изменить имя, уровень, лимит или теги участника команды
+func (uc *UseCase) UpdateTeamMember(ctx context.Context, update entity.TeamMemberUpdate) (*entity.TeamMemberResult, error) {
+	if update.TeamName == "" || update.UserID == "" {
+		return nil, fmt.Errorf("team name or user id is empty")
+	}
+
+	if update.Username != nil && *update.Username == "" {
+		return nil, fmt.Errorf("username is empty")
+	}
+
+	if update.Seniority != nil {
+		if err := validateSeniority(*update.Seniority); err != nil {
+			return nil, err
+		}
+	}
+
+	if update.MaxOpenReviews != nil && *update.MaxOpenReviews < 0 {
+		return nil, entity.ErrInvalidCapacity
+	}
+
+	if update.Tags != nil {
+		update.Tags = normalizeTags(update.Tags)
+	}
+
+	err := uc.repo.UpdateTeamMember(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := uc.repo.GetTeam(ctx, update.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TeamMemberResult{Team: team}, nil
+}
+
+// RemoveTeamMember - убрать участника из команды.
+// Открытые ревью участника на pr этой команды (из последней команды - все) передаются оставшимся
+// по правилам ReassignPrReviewer, если это запрошено; если ревью есть, а передача не запрошена
+// или невозможна хотя бы для одного pr, состав не меняется
+func (uc *UseCase) RemoveTeamMember(ctx context.Context, remove entity.TeamMemberRemove) (*entity.TeamMemberResult, error) {
+	if remove.TeamName == "" || remove.UserID == "" {
+		return nil, fmt.Errorf("team name or user id is empty")
+	}
+
+	teams, err := uc.repo.GetTeamsByUserID(ctx, remove.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(teams, remove.TeamName) {
+		return nil, entity.ErrNotFound
+	}
+
+	var filter func(pr entity.PullRequestShort) bool
+	if len(teams) > 1 {
+		filter = forTeam(remove.TeamName)
+	}
+
+	open, err := uc.openReviews(ctx, remove.UserID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(open) > 0 && !remove.ReassignReviews {
+		return nil, fmt.Errorf("%w: %s", entity.ErrHasOpenReviews, strings.Join(open, ", "))
+	}
+
+	handoffs, failed := uc.planHandoffs(ctx, remove.UserID, open)
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("%w: cannot reassign %s: %s", entity.ErrHasOpenReviews,
+			failed[0].PullRequestID, failed[0].Reason)
+	}
+
+	err = uc.repo.RemoveTeamMember(ctx, remove.TeamName, remove.UserID, handoffs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.TeamMemberResult{Reassigned: reassignedFrom(handoffs)}
+
+	result.Team, err = uc.repo.GetTeam(ctx, remove.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// MoveUserTeam - перевести пользователя из одной его команды в другую.
+// Если запрошено, открытые ревью pr старой команды передаются ее оставшимся участникам,
+// остальные ревью остаются за пользователем. Активному пользователю добираются ревью в новой команде
+func (uc *UseCase) MoveUserTeam(ctx context.Context, move entity.UserMove) (*entity.UserMoveResult, error) {
+	if move.UserID == "" || move.TeamName == "" {
+		return nil, fmt.Errorf("user id or team name is empty")
+	}
+
+	user, err := uc.repo.GetUser(ctx, move.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := uc.repo.GetTeamsByUserID(ctx, move.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(teams, move.TeamName) {
+		return nil, entity.ErrSameTeam
+	}
+
+	// из какой команды переводим: пользователь без команд просто присоединяется к новой
+	fromTeam := move.FromTeam
+	switch {
+	case fromTeam != "":
+		if !slices.Contains(teams, fromTeam) {
+			return nil, entity.ErrNotFound
+		}
+	case len(teams) == 1:
+		fromTeam = teams[0]
+	case len(teams) > 1:
+		return nil, entity.ErrTeamRequired
+	}
+
+	existTeam, err := uc.repo.CheckTeam(ctx, move.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existTeam {
+		return nil, entity.ErrNotFound
+	}
+
+	result := &entity.UserMoveResult{
+		UserID:   move.UserID,
+		FromTeam: fromTeam,
+		ToTeam:   move.TeamName,
+	}
+
+	open, err := uc.openReviews(ctx, move.UserID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	handoffs := []entity.ReviewHandoff{}
+	if move.ReassignReviews && fromTeam != "" {
+		oldTeamReviews, err := uc.openReviews(ctx, move.UserID, forTeam(fromTeam))
+		if err != nil {
+			return nil, err
+		}
+
+		handoffs, result.NotReassigned = uc.planHandoffs(ctx, move.UserID, oldTeamReviews)
+	}
+
+	err = uc.repo.MoveUserTeam(ctx, move.UserID, fromTeam, move.TeamName, handoffs)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Reassigned = reassignedFrom(handoffs)
+
+	moved := make(map[string]struct{}, len(handoffs))
+	for _, handoff := range handoffs {
+		moved[handoff.PullRequestID] = struct{}{}
+	}
+
+	for _, prID := range open {
+		if _, ok := moved[prID]; !ok {
+			result.KeptReviews = append(result.KeptReviews, prID)
+		}
+	}
+
+	// перевод уже выполнен: при ошибке добора результат возвращается вместе с ошибкой
+	if user.IsActive {
+		result.Backfilled, err = uc.backfillForUser(ctx, move.UserID)
+		if err != nil {
+			return result, fmt.Errorf("user moved, backfill failed: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// forTeam - фильтр pr, созданных для команды teamName
+func forTeam(teamName string) func(pr entity.PullRequestShort) bool {
+	return func(pr entity.PullRequestShort) bool {
+		return pr.TeamName == teamName
+	}
+}
+
+// openReviews - открытые pr, на которых пользователь ревьювер; filter отбирает pr, nil - все
+func (uc *UseCase) openReviews(ctx context.Context, userID string,
+	filter func(pr entity.PullRequestShort) bool) ([]string, error) {
+	reviews, err := uc.repo.GetReviewFromUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var open []string
+	for _, review := range reviews {
+		if review.Status != entity.PRStatusOpen {
+			continue
+		}
+
+		if filter != nil && !filter(review) {
+			continue
+		}
+
+		open = append(open, review.PullRequestID)
+	}
+
+	return open, nil
+}
+
+// planHandoffs - подобрать замены пользователю на pr, ничего не меняя.
+// pr, для которых замену найти не удалось, возвращаются отдельно с причиной
+func (uc *UseCase) planHandoffs(ctx context.Context, userID string,
+	prIDs []string) ([]entity.ReviewHandoff, []entity.FailedReassign) {
+	handoffs := []entity.ReviewHandoff{}
+	failed := []entity.FailedReassign{}
+
+	for _, prID := range prIDs {
+		newReviewerID, reason, err := uc.planReassign(ctx, prID, userID, "")
+		if err != nil {
+			failed = append(failed, entity.FailedReassign{
+				PullRequestID: prID,
+				Reason:        err.Error(),
+			})
+			continue
+		}
+
+		handoffs = append(handoffs, entity.ReviewHandoff{
+			PullRequestID: prID,
+			OldReviewerID: userID,
+			NewReviewerID: newReviewerID,
+			Reason:        reason,
+		})
+	}
+
+	return handoffs, failed
+}
+
+// reassignedFrom - выполненные передачи ревью в виде ответа
+func reassignedFrom(handoffs []entity.ReviewHandoff) []entity.ReassignedReview {
+	reassigned := make([]entity.ReassignedReview, 0, len(handoffs))
+	for _, handoff := range handoffs {
+		reassigned = append(reassigned, entity.ReassignedReview{
+			PullRequestID: handoff.PullRequestID,
+			OldReviewerID: handoff.OldReviewerID,
+			NewReviewerID: handoff.NewReviewerID,
+		})
+	}
+
+	return reassigned
+}
+
+// targetTeam - команда, для которой создается pr: явно указанная (автор должен в ней состоять), иначе единственная из команд автора,
+// которой принадлежит репозиторий, иначе основная команда автора
+func (uc *UseCase) targetTeam(ctx context.Context, pr entity.PullRequestCreate) (string, error) {
+	if pr.TeamName != "" {
+		existTeam, err := uc.repo.CheckTeam(ctx, pr.TeamName)
+		if err != nil {
+			return "", err
+		}
+
+		if !existTeam {
+			return "", entity.ErrNotFound
+		}
+
+		// pr нельзя отправить на ревью команде, в которой автор не состоит
+		authorTeams, err := uc.repo.GetTeamsByUserID(ctx, pr.AuthorID)
+		if err != nil {
+			return "", err
+		}
+
+		if !slices.Contains(authorTeams, pr.TeamName) {
+			return "", fmt.Errorf("%w: %s", entity.ErrNotTeamMember, pr.TeamName)
+		}
+
+		return pr.TeamName, nil
+	}
+
+	if pr.Repository != "" {
+		teamName, err := uc.repositoryTeam(ctx, pr.Repository, pr.AuthorID)
+		if err != nil {
+			return "", err
+		}
+
+		if teamName != "" {
+			return teamName, nil
+		}
+	}
+
+	return uc.repo.GetTeamByUserID(ctx, pr.AuthorID)
+}
+
+// repositoryTeam - единственная команда автора среди владельцев репозитория, пусто - такой нет или их несколько
+func (uc *UseCase) repositoryTeam(ctx context.Context, repositoryName, authorID string) (string, error) {
+	repository, err := uc.repo.GetRepository(ctx, repositoryName)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	authorTeams, err := uc.repo.GetTeamsByUserID(ctx, authorID)
+	if err != nil {
+		return "", err
+	}
+
+	var matched []string
+	for _, teamName := range authorTeams {
+		if slices.Contains(repository.Teams, teamName) {
+			matched = append(matched, teamName)
+		}
+	}
+
+	if len(matched) != 1 {
+		return "", nil
+	}
+
+	return matched[0], nil
+}
+
+// prTeam - команда, для которой создан pr; у pr, созданных до появления команд pr, - основная команда автора
+func (uc *UseCase) prTeam(ctx context.Context, pr entity.PullRequest) (string, error) {
+	if pr.TeamName != "" {
+		return pr.TeamName, nil
+	}
+
+	return uc.repo.GetTeamByUserID(ctx, pr.AuthorID)
+}
+
+// reviewerTeam - из какой команды ревьювер pr: команда pr, если он в ней состоит, иначе его основная команда
+func (uc *UseCase) reviewerTeam(ctx context.Context, reviewerID, prTeam string) (string, error) {
+	teams, err := uc.repo.GetTeamsByUserID(ctx, reviewerID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(teams) == 0 {
+		return "", entity.ErrNotFound
+	}
+
+	if slices.Contains(teams, prTeam) {
+		return prTeam, nil
+	}
+
+	return teams[0], nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+	"strings"
+)
+
+// unmetMergeConditions - условия политики мержа команды pr, которые pr не выполняет
+func (uc *UseCase) unmetMergeConditions(ctx context.Context, pr entity.PullRequest) ([]entity.UnmetCondition, error) {
+	teamName, err := uc.prTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := team.MergePolicy
+
+	var approvers, changesRequested []string
+	for _, reviewerID := range pr.AssignedReviewers {
+		switch pr.ReviewStates[reviewerID] {
+		case entity.ReviewStateApproved:
+			approvers = append(approvers, reviewerID)
+		case entity.ReviewStateChangesRequested:
+			changesRequested = append(changesRequested, reviewerID)
+		}
+	}
+
+	var unmet []entity.UnmetCondition
+
+	if len(approvers) < policy.MinApprovals {
+		unmet = append(unmet, entity.UnmetCondition{
+			Condition: entity.ConditionMinApprovals,
+			Message:   fmt.Sprintf("%d of %d required approvals", len(approvers), policy.MinApprovals),
+		})
+	}
+
+	if policy.BlockOnChangesRequested && len(changesRequested) > 0 {
+		unmet = append(unmet, entity.UnmetCondition{
+			Condition: entity.ConditionNoChangesRequested,
+			Message:   "changes requested by " + strings.Join(changesRequested, ", "),
+		})
+	}
+
+	if policy.RequireSeniorApproval {
+		approved, err := uc.hasSeniorApproval(ctx, approvers)
+		if err != nil {
+			return nil, err
+		}
+
+		if !approved {
+			unmet = append(unmet, entity.UnmetCondition{
+				Condition: entity.ConditionSeniorApproval,
+				Message:   "no approval from a senior reviewer",
+			})
+		}
+	}
+
+	if policy.RequireCodeOwnerApproval {
+		approved, err := uc.hasCodeOwnerApproval(ctx, teamName, pr.ChangedFiles, approvers)
+		if err != nil {
+			return nil, err
+		}
+
+		if !approved {
+			unmet = append(unmet, entity.UnmetCondition{
+				Condition: entity.ConditionCodeOwnerApproval,
+				Message:   "no approval from an owner of the changed files",
+			})
+		}
+	}
+
+	return unmet, nil
+}
+
+// hasSeniorApproval - среди одобривших есть senior
+func (uc *UseCase) hasSeniorApproval(ctx context.Context, approvers []string) (bool, error) {
+	for _, approverID := range approvers {
+		approver, err := uc.repo.GetUser(ctx, approverID)
+		if err != nil {
+			return false, err
+		}
+
+		if approver.Seniority == entity.SenioritySenior {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// hasCodeOwnerApproval - среди одобривших есть владелец изменённых файлов.
+// Если у файлов нет владельцев, условие считается выполненным
+func (uc *UseCase) hasCodeOwnerApproval(ctx context.Context, teamName string, changedFiles,
+	approvers []string) (bool, error) {
+	if len(changedFiles) == 0 {
+		return true, nil
+	}
+
+	owners, err := uc.codeOwnersFor(ctx, teamName, changedFiles)
+	if err != nil {
+		return false, err
+	}
+
+	// владельцы без команды выбраны ревьюверами быть не могут, но их одобрение засчитывается
+	ownerIDs := owners.ids()
+	if len(ownerIDs) == 0 {
+		return true, nil
+	}
+
+	ownerSet := make(map[string]struct{}, len(ownerIDs))
+	for _, ownerID := range ownerIDs {
+		ownerSet[ownerID] = struct{}{}
+	}
+
+	for _, approverID := range approvers {
+		if _, ok := ownerSet[approverID]; ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// newMergeOverride - запись аудита об обходе политики; обходящий должен быть администратором и указать причину
+func (uc *UseCase) newMergeOverride(ctx context.Context, req entity.MergeRequest,
+	unmet []entity.UnmetCondition) (*entity.MergeOverride, error) {
+	if req.OverrideBy == "" || strings.TrimSpace(req.OverrideReason) == "" {
+		return nil, entity.ErrInvalidOverride
+	}
+
+	user, err := uc.repo.GetUser(ctx, req.OverrideBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin {
+		return nil, fmt.Errorf("%w: %s", entity.ErrForbidden, req.OverrideBy)
+	}
+
+	return &entity.MergeOverride{
+		PullRequestID:   req.PullRequestID,
+		OverriddenBy:    req.OverrideBy,
+		Reason:          strings.TrimSpace(req.OverrideReason),
+		UnmetConditions: unmet,
+	}, nil
+}
+
+// GetMergeOverrides - аудит мержей в обход политики, prID фильтрует по pr
+func (uc *UseCase) GetMergeOverrides(ctx context.Context, prID string) ([]entity.MergeOverride, error) {
+	return uc.repo.GetMergeOverrides(ctx, prID)
+}
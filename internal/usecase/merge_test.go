@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr_reviewer_service/internal/entity"
+	"testing"
+)
+
+func TestNewMergeOverride(t *testing.T) {
+	repo := &fakeRepo{
+		teams: map[string]*entity.Team{
+			"backend": {TeamName: "backend", Members: activeMembers("admin", "dev")},
+		},
+		admins: map[string]bool{"admin": true},
+	}
+	uc := testUseCase(repo)
+
+	req := entity.MergeRequest{Override: true, OverrideBy: "admin", OverrideReason: " hotfix "}
+	override, err := uc.newMergeOverride(context.Background(), req, nil)
+	if err != nil {
+		t.Fatalf("newMergeOverride() error = %v", err)
+	}
+	if override.OverriddenBy != "admin" || override.Reason != "hotfix" {
+		t.Errorf("newMergeOverride() = %s/%q, want admin/%q", override.OverriddenBy, override.Reason, "hotfix")
+	}
+
+	tests := []struct {
+		name string
+		req  entity.MergeRequest
+		want error
+	}{
+		{name: "not an admin", req: entity.MergeRequest{OverrideBy: "dev", OverrideReason: "hotfix"}, want: entity.ErrForbidden},
+		{name: "unknown user", req: entity.MergeRequest{OverrideBy: "ghost", OverrideReason: "hotfix"}, want: entity.ErrNotFound},
+		{name: "no reason", req: entity.MergeRequest{OverrideBy: "admin", OverrideReason: " "}, want: entity.ErrInvalidOverride},
+		{name: "no user", req: entity.MergeRequest{OverrideReason: "hotfix"}, want: entity.ErrInvalidOverride},
+	}
+
+	for _, tt := range tests {
+		if _, err := uc.newMergeOverride(context.Background(), tt.req, nil); !errors.Is(err, tt.want) {
+			t.Errorf("%s: newMergeOverride() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
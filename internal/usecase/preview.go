@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"pr_reviewer_service/internal/entity"
+)
+
+// PreviewPullRequest - подбор ревьюверов как при создании pr, но без записи в базу.
+// Возвращает выбранных ревьюверов и весь пул кандидатов с причинами исключения
+func (uc *UseCase) PreviewPullRequest(ctx context.Context, pr entity.PullRequestCreate) (*entity.AssignmentPreview, error) {
+	if err := validatePRMetadata(pr.PRMetadata); err != nil {
+		return nil, err
+	}
+
+	if pr.ReviewersCount != nil && *pr.ReviewersCount <= 0 {
+		return nil, entity.ErrInvalidReviewers
+	}
+
+	pr.Labels = normalizeTags(pr.Labels)
+
+	if pr.ChangedFilesCount == nil && len(pr.ChangedFiles) > 0 {
+		count := len(pr.ChangedFiles)
+		pr.ChangedFilesCount = &count
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	teamName, err := uc.targetTeam(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	sizeTier, err := uc.applySizeTier(ctx, teamName, &pr)
+	if err != nil {
+		return nil, err
+	}
+
+	// состояние стратегий (например, очередь round_robin) и поток сидов не должны сдвигаться от предпросмотра
+	dryRun := *uc
+	dryRun.strategies = cloneStrategies(uc.strategies)
+	dryRun.seeds = uc.seeds.fork()
+
+	assigned, err := dryRun.generateReviewers(ctx, teamName, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := uc.describePool(ctx, teamName, pr, assigned)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &entity.AssignmentPreview{
+		AuthorID:          pr.AuthorID,
+		TeamName:          teamName,
+		AssignedReviewers: assigned.reviewers,
+		Pool:              pool,
+		SizeTier:          sizeTier,
+		AssignmentReason:  assigned.reasons,
+	}
+	if len(assigned.fallback) > 0 {
+		preview.FallbackReviewers = assigned.fallback
+	}
+
+	return preview, nil
+}
+
+// describePool - все кандидаты на pr: владельцы изменённых файлов, команда автора и резервные команды.
+// Причины исключения берутся из подбора assigned
+func (uc *UseCase) describePool(ctx context.Context, teamName string, pr entity.PullRequestCreate,
+	assigned *assignment) ([]entity.PoolMember, error) {
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	type poolEntry struct {
+		member   entity.TeamMember
+		teamName string
+		source   string
+	}
+
+	var entries []poolEntry
+	seen := make(map[string]struct{})
+	add := func(members []entity.TeamMember, teamName, source string) {
+		for _, m := range members {
+			if _, ok := seen[m.UserID]; ok {
+				continue
+			}
+			seen[m.UserID] = struct{}{}
+			entries = append(entries, poolEntry{member: m, teamName: teamName, source: source})
+		}
+	}
+
+	if len(pr.ChangedFiles) > 0 {
+		owners, err := uc.codeOwnersFor(ctx, teamName, pr.ChangedFiles)
+		if err != nil {
+			return nil, err
+		}
+		add(owners.members, "", entity.PoolSourceCodeOwner)
+
+		// владельцы без команды попадают в пул с причиной исключения no_team
+		for _, userID := range owners.unresolved {
+			user, err := uc.repo.GetUser(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			add([]entity.TeamMember{{UserID: user.UserID, Username: user.Username}}, "", entity.PoolSourceCodeOwner)
+		}
+	}
+
+	add(withTeamCapacity(team), team.TeamName, entity.PoolSourceTeam)
+
+	for _, fallbackName := range team.FallbackTeams {
+		fallbackTeam, err := uc.repo.GetTeam(ctx, fallbackName)
+		if err != nil {
+			return nil, err
+		}
+		add(withTeamCapacity(fallbackTeam), fallbackName, entity.PoolSourceFallback)
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.member.UserID)
+	}
+
+	load, err := uc.repo.GetOpenReviewCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedSet := make(map[string]struct{}, len(assigned.reviewers))
+	for _, id := range assigned.reviewers {
+		selectedSet[id] = struct{}{}
+	}
+
+	req := assignRequest{authorID: pr.AuthorID, exclude: []string{pr.AuthorID}}
+
+	pool := make([]entity.PoolMember, 0, len(entries))
+	for _, e := range entries {
+		_, isSelected := selectedSet[e.member.UserID]
+
+		poolMember := entity.PoolMember{
+			UserID:      e.member.UserID,
+			Username:    e.member.Username,
+			TeamName:    e.teamName,
+			Source:      e.source,
+			OpenReviews: load[e.member.UserID],
+			Selected:    isSelected,
+		}
+		if !isSelected {
+			reason, ok := assigned.excluded[e.member.UserID]
+			if !ok {
+				// пулы, до которых подбор не дошел, проверяются теми же условиями
+				set := candidatesFor([]entity.TeamMember{e.member}, assigned, req)
+				withinCapacity(set.members, load, set.excluded)
+				reason = set.excluded[e.member.UserID]
+			}
+			poolMember.Excluded = reason
+		}
+
+		pool = append(pool, poolMember)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+	"testing"
+)
+
+func TestPreviewPullRequest(t *testing.T) {
+	limit := 1
+	members := append(activeMembers("author", "u1", "u2", "u3", "u4"), entity.TeamMember{UserID: "off", IsActive: false})
+	members[1].MaxOpenReviews = &limit
+
+	repo := &fakeRepo{
+		teams: map[string]*entity.Team{
+			"backend": {TeamName: "backend", ReviewersCount: 2, Members: members},
+		},
+		load: map[string]int{"u1": 1},
+	}
+	uc := testUseCase(repo)
+	pr := entity.PullRequestCreate{PullRequestID: "pr-1", AuthorID: "author"}
+
+	first, err := uc.PreviewPullRequest(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("PreviewPullRequest() error = %v", err)
+	}
+
+	second, err := uc.PreviewPullRequest(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("PreviewPullRequest() error = %v", err)
+	}
+
+	// предпросмотр не сдвигает подбор: следующий pr получит тех же ревьюверов
+	created, err := uc.generateReviewers(context.Background(), "backend", pr)
+	if err != nil {
+		t.Fatalf("generateReviewers() error = %v", err)
+	}
+
+	if !slices.Equal(first.AssignedReviewers, second.AssignedReviewers) || !slices.Equal(first.AssignedReviewers, created.reviewers) {
+		t.Errorf("previews = %v, %v, then created %v, want the same reviewers",
+			first.AssignedReviewers, second.AssignedReviewers, created.reviewers)
+	}
+	checkReviewers(t, first.AssignedReviewers, "author", "u2", "u3", "u4")
+
+	wantExcluded := map[string]string{
+		"author": entity.ExcludedAuthor,
+		"u1":     entity.ExcludedOverCapacity,
+		"off":    entity.ExcludedInactive,
+	}
+	for _, m := range first.Pool {
+		if m.Excluded != wantExcluded[m.UserID] {
+			t.Errorf("pool member %s excluded = %q, want %q", m.UserID, m.Excluded, wantExcluded[m.UserID])
+		}
+
+		if m.Selected != slices.Contains(first.AssignedReviewers, m.UserID) {
+			t.Errorf("pool member %s selected = %v, want %v", m.UserID, m.Selected, !m.Selected)
+		}
+	}
+
+	if len(first.Pool) != len(members) {
+		t.Errorf("pool = %+v, want all %d team members", first.Pool, len(members))
+	}
+}
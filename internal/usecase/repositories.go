@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+)
+
+// ResolvePullRequestID - pull_request_id pr по ссылке: переданный явно или найденный по repository + number
+func (uc *UseCase) ResolvePullRequestID(ctx context.Context, ref entity.PRRef) (string, error) {
+	if ref.PullRequestID != "" {
+		return ref.PullRequestID, nil
+	}
+
+	if ref.Repository == "" || ref.Number == nil || *ref.Number <= 0 {
+		return "", entity.ErrInvalidPRRef
+	}
+
+	return uc.repo.GetPRIDByNumber(ctx, ref.Repository, *ref.Number)
+}
+
+// SaveRepository - зарегистрировать репозиторий и задать команды, которым он принадлежит
+func (uc *UseCase) SaveRepository(ctx context.Context, repository entity.Repository) (*entity.Repository, error) {
+	if repository.RepositoryName == "" {
+		return nil, fmt.Errorf("repository name is empty")
+	}
+
+	teams := make([]string, 0, len(repository.Teams))
+	seen := make(map[string]struct{}, len(repository.Teams))
+	for _, teamName := range repository.Teams {
+		if _, ok := seen[teamName]; ok {
+			continue
+		}
+		seen[teamName] = struct{}{}
+
+		existTeam, err := uc.repo.CheckTeam(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !existTeam {
+			return nil, entity.ErrNotFound
+		}
+
+		teams = append(teams, teamName)
+	}
+	repository.Teams = teams
+
+	err := uc.repo.SaveRepository(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetRepository(ctx, repository.RepositoryName)
+}
+
+// GetRepository - получить репозиторий и его команды
+func (uc *UseCase) GetRepository(ctx context.Context, repositoryName string) (*entity.Repository, error) {
+	if repositoryName == "" {
+		return nil, fmt.Errorf("repository name is empty")
+	}
+
+	return uc.repo.GetRepository(ctx, repositoryName)
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+)
+
+// AddReviewer - добавить ревьювера на открытый pr вручную
+func (uc *UseCase) AddReviewer(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error) {
+	pr, err := uc.openPR(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, err := uc.checkAddedReviewer(ctx, *pr, change.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := map[string]entity.AssignmentReason{change.ReviewerID: reason}
+
+	err = uc.repo.AddPrReviewers(ctx, change.PullRequestID, []string{change.ReviewerID}, reasons)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetPullRequest(ctx, change.PullRequestID)
+}
+
+// checkAddedReviewer - проверить ревьювера, добавляемого вручную, по тем же пулам и условиям, что и при подборе:
+// владельцы изменённых файлов pr, затем команда pr и ее резервные команды
+func (uc *UseCase) checkAddedReviewer(ctx context.Context, pr entity.PullRequest,
+	reviewerID string) (entity.AssignmentReason, error) {
+	if slices.Contains(pr.AssignedReviewers, reviewerID) {
+		return entity.AssignmentReason{}, entity.ErrAlreadyAssigned
+	}
+
+	prTeam, err := uc.prTeam(ctx, pr)
+	if err != nil {
+		return entity.AssignmentReason{}, err
+	}
+
+	req := assignRequest{
+		authorID: pr.AuthorID,
+		exclude:  append([]string{pr.AuthorID}, pr.AssignedReviewers...),
+		labels:   pr.Labels,
+	}
+
+	reason, isOwner, err := uc.checkCodeOwner(ctx, prTeam, reviewerID, pr.ChangedFiles, req)
+	if err != nil || isOwner {
+		return reason, err
+	}
+
+	return uc.checkNewReviewer(ctx, prTeam, reviewerID, req)
+}
+
+// RemoveReviewer - снять ревьювера с открытого pr
+func (uc *UseCase) RemoveReviewer(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error) {
+	pr, err := uc.openPR(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == change.ReviewerID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, entity.ErrNotAssigned
+	}
+
+	err = uc.repo.RemovePrReviewer(ctx, change.PullRequestID, change.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetPullRequest(ctx, change.PullRequestID)
+}
+
+// openPR - pr, состав ревьюверов которого можно менять
+func (uc *UseCase) openPR(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error) {
+	if change.PullRequestID == "" || change.ReviewerID == "" {
+		return nil, fmt.Errorf("pull request id or reviewer id is empty")
+	}
+
+	pr, err := uc.GetPullRequest(ctx, change.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requireOpen(*pr); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// requireOpen - ревьюверов можно менять только у OPEN pr
+func requireOpen(pr entity.PullRequest) error {
+	switch pr.Status {
+	case entity.PRStatusOpen:
+		return nil
+	case entity.PRStatusMerged:
+		return entity.ErrPrMerged
+	}
+
+	return fmt.Errorf("%w: %s", entity.ErrPrNotOpen, pr.Status)
+}
+
+// SubmitReview - ревьювер отправляет ревью на открытый pr
+func (uc *UseCase) SubmitReview(ctx context.Context, review entity.ReviewSubmit) (*entity.PullRequest, error) {
+	if err := validateReviewState(review.State); err != nil {
+		return nil, err
+	}
+
+	// PENDING - начальное состояние, его не отправляют
+	if review.State == entity.ReviewStatePending {
+		return nil, entity.ErrInvalidReviewState
+	}
+
+	pr, err := uc.openPR(ctx, entity.PrReviewerChange{
+		PRRef:      review.PRRef,
+		ReviewerID: review.ReviewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := pr.ReviewStates[review.ReviewerID]; !ok {
+		return nil, entity.ErrNotAssigned
+	}
+
+	err = uc.repo.SetReviewState(ctx, review.PullRequestID, review.ReviewerID, review.State)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.GetPullRequest(ctx, review.PullRequestID)
+}
+
+// validateReviewState - проверить, что состояние ревью известно
+func validateReviewState(state string) error {
+	switch state {
+	case entity.ReviewStatePending, entity.ReviewStateApproved,
+		entity.ReviewStateChangesRequested, entity.ReviewStateDismissed:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", entity.ErrInvalidReviewState, state)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr_reviewer_service/internal/entity"
+	"testing"
+)
+
+func TestValidateReviewState(t *testing.T) {
+	tests := []struct {
+		state   string
+		wantErr bool
+	}{
+		{state: entity.ReviewStatePending},
+		{state: entity.ReviewStateApproved},
+		{state: entity.ReviewStateChangesRequested},
+		{state: entity.ReviewStateDismissed},
+		{state: "", wantErr: true},
+		{state: "approved", wantErr: true},
+		{state: "COMMENTED", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateReviewState(tt.state)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("validateReviewState(%q) = %v, want error: %v", tt.state, err, tt.wantErr)
+		}
+
+		if err != nil && !errors.Is(err, entity.ErrInvalidReviewState) {
+			t.Errorf("validateReviewState(%q) = %v, want ErrInvalidReviewState", tt.state, err)
+		}
+	}
+}
+
+func TestRequireOpen(t *testing.T) {
+	tests := []struct {
+		status entity.PRStatus
+		want   error
+	}{
+		{status: entity.PRStatusOpen},
+		{status: entity.PRStatusMerged, want: entity.ErrPrMerged},
+		{status: entity.PRStatusDraft, want: entity.ErrPrNotOpen},
+		{status: entity.PRStatusClosed, want: entity.ErrPrNotOpen},
+	}
+
+	for _, tt := range tests {
+		err := requireOpen(entity.PullRequest{Status: tt.status})
+		if tt.want == nil && err != nil {
+			t.Errorf("requireOpen(%s) = %v, want nil", tt.status, err)
+		}
+
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("requireOpen(%s) = %v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
+
+func TestCheckAddedReviewer(t *testing.T) {
+	repo := &fakeRepo{
+		teams: map[string]*entity.Team{
+			"backend":  {TeamName: "backend", FallbackTeams: []string{"platform"}, Members: activeMembers("author", "u1", "r1")},
+			"platform": {TeamName: "platform", Members: activeMembers("p1")},
+			"frontend": {TeamName: "frontend", Members: activeMembers("f1", "owner")},
+		},
+		rules: []entity.CodeOwnerRule{{Pattern: "*.go", Users: []string{"owner"}}},
+	}
+	uc := testUseCase(repo)
+
+	pr := entity.PullRequest{
+		AuthorID:          "author",
+		AssignedReviewers: []string{"r1"},
+		TeamName:          "backend",
+		ChangedFiles:      []string{"cmd/main.go"},
+	}
+
+	sources := map[string]string{
+		"u1":    entity.PoolSourceTeam,
+		"p1":    entity.PoolSourceFallback,
+		"owner": entity.PoolSourceCodeOwner,
+	}
+	for reviewerID, want := range sources {
+		reason, err := uc.checkAddedReviewer(context.Background(), pr, reviewerID)
+		if err != nil {
+			t.Fatalf("checkAddedReviewer(%s) error = %v", reviewerID, err)
+		}
+		if reason.Source != want || reason.Strategy != StrategyManual {
+			t.Errorf("checkAddedReviewer(%s) = %s/%s, want %s/%s", reviewerID, reason.Strategy, reason.Source,
+				StrategyManual, want)
+		}
+	}
+
+	errs := map[string]error{
+		"r1":     entity.ErrAlreadyAssigned,
+		"author": entity.ErrNotCandidate,
+		"f1":     entity.ErrNotCandidate, // чужая команда и не владелец файлов
+	}
+	for reviewerID, want := range errs {
+		_, err := uc.checkAddedReviewer(context.Background(), pr, reviewerID)
+		if !errors.Is(err, want) {
+			t.Errorf("checkAddedReviewer(%s) error = %v, want %v", reviewerID, err, want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"pr_reviewer_service/internal/entity"
+)
+
+// applySizeTier - выбрать количество ревьюверов по размеру pr, если оно не задано в запросе.
+// Возвращает имя выбранного уровня
+func (uc *UseCase) applySizeTier(ctx context.Context, teamName string, pr *entity.PullRequestCreate) (string, error) {
+	if pr.ReviewersCount != nil {
+		return "", nil
+	}
+
+	team, err := uc.repo.GetTeam(ctx, teamName)
+	if err != nil {
+		return "", err
+	}
+
+	tier := sizeTierFor(team.SizeTiers, pr.PRMetadata)
+	if tier == nil {
+		return "", nil
+	}
+
+	count := tier.ReviewersCount
+	pr.ReviewersCount = &count
+
+	return tier.Name, nil
+}
+
+// validateSizeTiers - проверить уровни размера команды
+func validateSizeTiers(tiers []entity.SizeTier) error {
+	seen := make(map[string]struct{}, len(tiers))
+
+	for _, tier := range tiers {
+		if tier.Name == "" {
+			return fmt.Errorf("%w: name is empty", entity.ErrInvalidSizeTier)
+		}
+
+		if _, ok := seen[tier.Name]; ok {
+			return fmt.Errorf("%w: duplicate %s", entity.ErrInvalidSizeTier, tier.Name)
+		}
+		seen[tier.Name] = struct{}{}
+
+		if tier.MinLines < 0 || tier.MinFiles < 0 {
+			return fmt.Errorf("%w: %s: thresholds must not be negative", entity.ErrInvalidSizeTier, tier.Name)
+		}
+
+		if tier.ReviewersCount <= 0 {
+			return fmt.Errorf("%w: %s: reviewers count must be positive", entity.ErrInvalidSizeTier, tier.Name)
+		}
+	}
+
+	return nil
+}
+
+// sizeTierFor - уровень размера pr: из подходящих выбирается тот, что требует больше ревьюверов.
+// Если размер pr неизвестен, уровни не применяются
+func sizeTierFor(tiers []entity.SizeTier, meta entity.PRMetadata) *entity.SizeTier {
+	lines, hasLines := linesChanged(meta)
+	hasFiles := meta.ChangedFilesCount != nil
+
+	if !hasLines && !hasFiles {
+		return nil
+	}
+
+	var chosen *entity.SizeTier
+	for i, tier := range tiers {
+		matched := tier.MinLines == 0 && tier.MinFiles == 0
+		if tier.MinLines > 0 && hasLines && lines >= tier.MinLines {
+			matched = true
+		}
+		if tier.MinFiles > 0 && hasFiles && *meta.ChangedFilesCount >= tier.MinFiles {
+			matched = true
+		}
+
+		if matched && (chosen == nil || tier.ReviewersCount > chosen.ReviewersCount) {
+			chosen = &tiers[i]
+		}
+	}
+
+	return chosen
+}
+
+// linesChanged - сколько строк добавлено и удалено, если известно хотя бы одно из чисел
+func linesChanged(meta entity.PRMetadata) (int, bool) {
+	if meta.Additions == nil && meta.Deletions == nil {
+		return 0, false
+	}
+
+	lines := 0
+	if meta.Additions != nil {
+		lines += *meta.Additions
+	}
+	if meta.Deletions != nil {
+		lines += *meta.Deletions
+	}
+
+	return lines, true
+}
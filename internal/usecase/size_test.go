@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"pr_reviewer_service/internal/entity"
+	"testing"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestSizeTierFor(t *testing.T) {
+	tiers := []entity.SizeTier{
+		{Name: "small", ReviewersCount: 1},
+		{Name: "medium", MinLines: 200, ReviewersCount: 2},
+		{Name: "large", MinLines: 1000, MinFiles: 30, ReviewersCount: 3},
+	}
+
+	tests := []struct {
+		name string
+		meta entity.PRMetadata
+		want string
+	}{
+		{name: "unknown size", meta: entity.PRMetadata{}, want: ""},
+		{name: "tier without thresholds matches any known size", meta: entity.PRMetadata{Additions: intPtr(10)}, want: "small"},
+		{name: "zero lines", meta: entity.PRMetadata{Additions: intPtr(0), Deletions: intPtr(0)}, want: "small"},
+		{name: "additions and deletions are summed", meta: entity.PRMetadata{Additions: intPtr(150), Deletions: intPtr(60)}, want: "medium"},
+		{name: "only deletions known", meta: entity.PRMetadata{Deletions: intPtr(250)}, want: "medium"},
+		{name: "threshold is inclusive", meta: entity.PRMetadata{Additions: intPtr(200)}, want: "medium"},
+		{name: "just below threshold", meta: entity.PRMetadata{Additions: intPtr(199)}, want: "small"},
+		{name: "most reviewers wins", meta: entity.PRMetadata{Additions: intPtr(5000)}, want: "large"},
+		{name: "files alone match", meta: entity.PRMetadata{ChangedFilesCount: intPtr(40)}, want: "large"},
+		{name: "files below threshold, lines unknown", meta: entity.PRMetadata{ChangedFilesCount: intPtr(3)}, want: "small"},
+		{name: "either threshold is enough", meta: entity.PRMetadata{Additions: intPtr(10), ChangedFilesCount: intPtr(30)}, want: "large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if tier := sizeTierFor(tiers, tt.meta); tier != nil {
+				got = tier.Name
+			}
+
+			if got != tt.want {
+				t.Errorf("sizeTierFor() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSizeTierForEdgeCases(t *testing.T) {
+	meta := entity.PRMetadata{Additions: intPtr(500)}
+
+	if tier := sizeTierFor(nil, meta); tier != nil {
+		t.Errorf("sizeTierFor() without tiers = %q, want none", tier.Name)
+	}
+
+	// при равном количестве ревьюверов остается уровень, указанный первым
+	tiers := []entity.SizeTier{
+		{Name: "first", MinLines: 100, ReviewersCount: 2},
+		{Name: "second", MinLines: 300, ReviewersCount: 2},
+	}
+	if tier := sizeTierFor(tiers, meta); tier == nil || tier.Name != "first" {
+		t.Errorf("sizeTierFor() with equal reviewers = %v, want first", tier)
+	}
+
+	// ни один порог не достигнут
+	tiers = []entity.SizeTier{{Name: "huge", MinLines: 1000, MinFiles: 50, ReviewersCount: 4}}
+	if tier := sizeTierFor(tiers, meta); tier != nil {
+		t.Errorf("sizeTierFor() below all thresholds = %q, want none", tier.Name)
+	}
+}
+
+func TestLinesChanged(t *testing.T) {
+	tests := []struct {
+		name      string
+		meta      entity.PRMetadata
+		wantLines int
+		wantKnown bool
+	}{
+		{name: "unknown", meta: entity.PRMetadata{}},
+		{name: "only files", meta: entity.PRMetadata{ChangedFilesCount: intPtr(3)}},
+		{name: "additions", meta: entity.PRMetadata{Additions: intPtr(7)}, wantLines: 7, wantKnown: true},
+		{name: "deletions", meta: entity.PRMetadata{Deletions: intPtr(4)}, wantLines: 4, wantKnown: true},
+		{name: "both", meta: entity.PRMetadata{Additions: intPtr(7), Deletions: intPtr(4)}, wantLines: 11, wantKnown: true},
+	}
+
+	for _, tt := range tests {
+		lines, known := linesChanged(tt.meta)
+		if lines != tt.wantLines || known != tt.wantKnown {
+			t.Errorf("%s: linesChanged() = %d, %v, want %d, %v", tt.name, lines, known, tt.wantLines, tt.wantKnown)
+		}
+	}
+}
+
+func TestValidateSizeTiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		tiers   []entity.SizeTier
+		wantErr bool
+	}{
+		{name: "empty list"},
+		{name: "valid", tiers: []entity.SizeTier{
+			{Name: "small", ReviewersCount: 1},
+			{Name: "large", MinLines: 500, MinFiles: 20, ReviewersCount: 3},
+		}},
+		{name: "empty name", tiers: []entity.SizeTier{{ReviewersCount: 1}}, wantErr: true},
+		{name: "duplicate name", tiers: []entity.SizeTier{
+			{Name: "small", ReviewersCount: 1},
+			{Name: "small", MinLines: 10, ReviewersCount: 2},
+		}, wantErr: true},
+		{name: "negative lines", tiers: []entity.SizeTier{{Name: "a", MinLines: -1, ReviewersCount: 1}}, wantErr: true},
+		{name: "negative files", tiers: []entity.SizeTier{{Name: "a", MinFiles: -1, ReviewersCount: 1}}, wantErr: true},
+		{name: "zero reviewers", tiers: []entity.SizeTier{{Name: "a"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateSizeTiers(tt.tiers)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: validateSizeTiers() = %v, want error: %v", tt.name, err, tt.wantErr)
+		}
+
+		if err != nil && !errors.Is(err, entity.ErrInvalidSizeTier) {
+			t.Errorf("%s: validateSizeTiers() = %v, want ErrInvalidSizeTier", tt.name, err)
+		}
+	}
+}
+
+func TestApplySizeTier(t *testing.T) {
+	repo := &fakeRepo{teams: map[string]*entity.Team{
+		"backend": {
+			TeamName:  "backend",
+			SizeTiers: []entity.SizeTier{{Name: "large", MinLines: 100, ReviewersCount: 3}},
+		},
+	}}
+	uc := testUseCase(repo)
+
+	pr := entity.PullRequestCreate{PRMetadata: entity.PRMetadata{Additions: intPtr(150)}}
+	tier, err := uc.applySizeTier(context.Background(), "backend", &pr)
+	if err != nil {
+		t.Fatalf("applySizeTier() error = %v", err)
+	}
+	if tier != "large" || pr.ReviewersCount == nil || *pr.ReviewersCount != 3 {
+		t.Errorf("applySizeTier() = %q, reviewers %v, want large with 3 reviewers", tier, pr.ReviewersCount)
+	}
+
+	// количество из запроса важнее уровня
+	pr = entity.PullRequestCreate{ReviewersCount: intPtr(1), PRMetadata: entity.PRMetadata{Additions: intPtr(150)}}
+	tier, err = uc.applySizeTier(context.Background(), "backend", &pr)
+	if err != nil {
+		t.Fatalf("applySizeTier() error = %v", err)
+	}
+	if tier != "" || *pr.ReviewersCount != 1 {
+		t.Errorf("applySizeTier() with explicit count = %q, reviewers %d, want no tier and 1", tier, *pr.ReviewersCount)
+	}
+
+	// уровень не подошел - количество остается за командой
+	pr = entity.PullRequestCreate{PRMetadata: entity.PRMetadata{Additions: intPtr(10)}}
+	tier, err = uc.applySizeTier(context.Background(), "backend", &pr)
+	if err != nil {
+		t.Fatalf("applySizeTier() error = %v", err)
+	}
+	if tier != "" || pr.ReviewersCount != nil {
+		t.Errorf("applySizeTier() for small PR = %q, reviewers %v, want none", tier, pr.ReviewersCount)
+	}
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"fmt"
+	"math/rand"
+	"pr_reviewer_service/internal/entity"
+	"sort"
+	"sync"
+)
+
+// Названия встроенных стратегий назначения
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyWeighted    = "weighted"
+)
+
+// StrategyManual - ревьювер выбран вызывающим, а не стратегией
+const StrategyManual = "manual"
+
+// CandidatePool - кандидаты, из которых стратегия выбирает ревьюверов
+type CandidatePool struct {
+	TeamName   string
+	Candidates []string
+	Load       map[string]int // количество OPEN pr на ревью у кандидата
+	Rand       *rand.Rand     // источник случайности текущего подбора
+}
+
+// AssignmentStrategy - стратегия выбора ревьюверов
+type AssignmentStrategy interface {
+	Name() string
+	Pick(pool CandidatePool, count int) []string
+}
+
+// loadBasedStrategy - стратегия, которой нужна текущая нагрузка кандидатов
+type loadBasedStrategy interface {
+	usesLoad()
+}
+
+// statefulStrategy - стратегия, которая запоминает предыдущие выборы
+type statefulStrategy interface {
+	clone() AssignmentStrategy
+}
+
+// newStrategies - встроенные стратегии по названию
+func newStrategies() map[string]AssignmentStrategy {
+	strategies := []AssignmentStrategy{
+		&randomStrategy{},
+		&roundRobinStrategy{last: make(map[string]string)},
+		&leastLoadedStrategy{},
+		&weightedStrategy{},
+	}
+
+	result := make(map[string]AssignmentStrategy, len(strategies))
+	for _, s := range strategies {
+		result[s.Name()] = s
+	}
+
+	return result
+}
+
+// cloneStrategies - копия стратегий, выбор в которой не меняет состояние исходных
+func cloneStrategies(strategies map[string]AssignmentStrategy) map[string]AssignmentStrategy {
+	result := make(map[string]AssignmentStrategy, len(strategies))
+	for name, s := range strategies {
+		if stateful, ok := s.(statefulStrategy); ok {
+			s = stateful.clone()
+		}
+		result[name] = s
+	}
+
+	return result
+}
+
+// validateStrategy - проверить, что стратегия с таким названием существует
+func validateStrategy(strategies map[string]AssignmentStrategy, name string) error {
+	if _, ok := strategies[name]; !ok {
+		return fmt.Errorf("%w: %s", entity.ErrUnknownStrategy, name)
+	}
+
+	return nil
+}
+
+// randomStrategy - случайный выбор
+type randomStrategy struct{}
+
+// Name - название стратегии
+func (s *randomStrategy) Name() string {
+	return StrategyRandom
+}
+
+// Pick - перемешиваем кандидатов и берем первых count
+func (s *randomStrategy) Pick(pool CandidatePool, count int) []string {
+	candidates := append([]string(nil), pool.Candidates...)
+
+	pool.Rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	return limit(candidates, count)
+}
+
+// roundRobinStrategy - выбор по кругу внутри команды
+type roundRobinStrategy struct {
+	mu   sync.Mutex
+	last map[string]string // последний выбранный ревьювер по команде
+}
+
+// Name - название стратегии
+func (s *roundRobinStrategy) Name() string {
+	return StrategyRoundRobin
+}
+
+func (s *roundRobinStrategy) clone() AssignmentStrategy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last := make(map[string]string, len(s.last))
+	for team, userID := range s.last {
+		last[team] = userID
+	}
+
+	return &roundRobinStrategy{last: last}
+}
+
+// Pick - берем кандидатов по порядку, начиная со следующего после последнего выбранного
+func (s *roundRobinStrategy) Pick(pool CandidatePool, count int) []string {
+	candidates := append([]string(nil), pool.Candidates...)
+	if len(candidates) == 0 || count <= 0 {
+		return nil
+	}
+	sort.Strings(candidates)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// список кандидатов меняется, поэтому ищем позицию по id, а не по индексу
+	start := sort.SearchStrings(candidates, s.last[pool.TeamName])
+	if start < len(candidates) && candidates[start] == s.last[pool.TeamName] {
+		start++
+	}
+
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+
+	picked := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		picked = append(picked, candidates[(start+i)%len(candidates)])
+	}
+
+	s.last[pool.TeamName] = picked[len(picked)-1]
+
+	return picked
+}
+
+// leastLoadedStrategy - выбор наименее загруженных, при равенстве случайно
+type leastLoadedStrategy struct{}
+
+// Name - название стратегии
+func (s *leastLoadedStrategy) Name() string {
+	return StrategyLeastLoaded
+}
+
+func (s *leastLoadedStrategy) usesLoad() {}
+
+// Pick - сортируем по количеству открытых ревью
+func (s *leastLoadedStrategy) Pick(pool CandidatePool, count int) []string {
+	candidates := append([]string(nil), pool.Candidates...)
+
+	// сначала перемешиваем, чтобы стабильная сортировка разбивала ничьи случайно
+	pool.Rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return pool.Load[candidates[i]] < pool.Load[candidates[j]]
+	})
+
+	return limit(candidates, count)
+}
+
+// weightedStrategy - случайный выбор с весом, обратным нагрузке
+type weightedStrategy struct{}
+
+// Name - название стратегии
+func (s *weightedStrategy) Name() string {
+	return StrategyWeighted
+}
+
+func (s *weightedStrategy) usesLoad() {}
+
+// Pick - вес кандидата 1/(1+load), выбираем без повторений
+func (s *weightedStrategy) Pick(pool CandidatePool, count int) []string {
+	candidates := append([]string(nil), pool.Candidates...)
+
+	var picked []string
+	for len(picked) < count && len(candidates) > 0 {
+		weights := make([]float64, len(candidates))
+		var total float64
+		for i, id := range candidates {
+			weights[i] = 1 / float64(1+pool.Load[id])
+			total += weights[i]
+		}
+
+		idx := len(candidates) - 1
+		point := pool.Rand.Float64() * total
+		for i, w := range weights {
+			if point < w {
+				idx = i
+				break
+			}
+			point -= w
+		}
+
+		picked = append(picked, candidates[idx])
+		candidates = append(candidates[:idx], candidates[idx+1:]...)
+	}
+
+	return picked
+}
+
+// seedSource - потокобезопасный источник сидов; каждый подбор получает свой сид, чтобы его можно было повторить
+type seedSource struct {
+	mu      sync.Mutex
+	src     rand.Source
+	pending *int64 // сид, уже выданный копии, но еще не выданный из этого источника
+}
+
+// newSeedSource - источник сидов поверх src
+func newSeedSource(src rand.Source) *seedSource {
+	return &seedSource{src: src}
+}
+
+// next - сид для очередного подбора
+func (s *seedSource) next() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending != nil {
+		seed := *s.pending
+		s.pending = nil
+		return seed
+	}
+
+	return s.src.Int63()
+}
+
+// fork - копия источника для подбора без записи: ее первый сид совпадает со следующим сидом исходного,
+// а последовательность сидов исходного источника не меняется
+func (s *seedSource) fork() *seedSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending == nil {
+		seed := s.src.Int63()
+		s.pending = &seed
+	}
+
+	seed := *s.pending
+
+	return &seedSource{src: rand.NewSource(seed), pending: &seed}
+}
+
+// limit - обрезать список до count элементов
+func limit(ids []string, count int) []string {
+	if len(ids) > count {
+		return ids[:count]
+	}
+
+	return ids
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"errors"
+	"math/rand"
+	"pr_reviewer_service/internal/entity"
+	"slices"
+	"testing"
+)
+
+// testSeed - сид тестов; проверки не зависят от его значения
+const testSeed = 42
+
+func testPool(candidates []string, load map[string]int, seed int64) CandidatePool {
+	return CandidatePool{
+		TeamName:   "backend",
+		Candidates: candidates,
+		Load:       load,
+		Rand:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// checkPicked - выбраны разные кандидаты из пула, и их столько, сколько просили, если кандидатов хватает
+func checkPicked(t *testing.T, pool CandidatePool, count int, picked []string) {
+	t.Helper()
+
+	if want := min(count, len(pool.Candidates)); len(picked) != want {
+		t.Errorf("Pick(%d) = %v, want %d reviewers", count, picked, want)
+	}
+
+	seen := make(map[string]bool, len(picked))
+	for _, id := range picked {
+		if !slices.Contains(pool.Candidates, id) {
+			t.Errorf("Pick(%d) = %v, %s is not a candidate", count, picked, id)
+		}
+		if seen[id] {
+			t.Errorf("Pick(%d) = %v, %s picked twice", count, picked, id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestStrategyPick(t *testing.T) {
+	candidates := []string{"u1", "u2", "u3", "u4", "u5"}
+	load := map[string]int{"u1": 3, "u2": 0, "u3": 1, "u4": 0, "u5": 2}
+
+	for name := range newStrategies() {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(1); seed <= 20; seed++ {
+				for _, count := range []int{0, 1, 2, 5, 7} {
+					pool := testPool(candidates, load, seed)
+					checkPicked(t, pool, count, newStrategies()[name].Pick(pool, count))
+				}
+			}
+
+			if got := newStrategies()[name].Pick(testPool(nil, nil, testSeed), 2); len(got) != 0 {
+				t.Errorf("Pick() from empty pool = %v, want none", got)
+			}
+		})
+	}
+}
+
+func TestLeastLoadedPick(t *testing.T) {
+	strategy := newStrategies()[StrategyLeastLoaded]
+	candidates := []string{"u1", "u2", "u3", "u4", "u5", "u6"}
+	load := map[string]int{"u1": 3, "u2": 0, "u3": 1, "u4": 0, "u5": 2, "u6": 1}
+
+	for seed := int64(1); seed <= 20; seed++ {
+		count := 1 + int(seed)%len(candidates)
+		picked := strategy.Pick(testPool(candidates, load, seed), count)
+
+		// никто из невыбранных не загружен меньше выбранных
+		maxPicked := 0
+		for i, id := range picked {
+			if i > 0 && load[id] < load[picked[i-1]] {
+				t.Errorf("Pick(%d) = %v, not ordered by load", count, picked)
+			}
+			maxPicked = max(maxPicked, load[id])
+		}
+		for _, id := range candidates {
+			if !slices.Contains(picked, id) && load[id] < maxPicked {
+				t.Errorf("Pick(%d) = %v, skipped less loaded %s", count, picked, id)
+			}
+		}
+	}
+}
+
+func TestStrategyPickSameSeed(t *testing.T) {
+	candidates := []string{"u1", "u2", "u3", "u4", "u5", "u6"}
+	load := map[string]int{"u1": 1, "u2": 1, "u3": 0, "u4": 2, "u5": 0, "u6": 1}
+
+	for _, name := range []string{StrategyRandom, StrategyLeastLoaded, StrategyWeighted} {
+		t.Run(name, func(t *testing.T) {
+			for seed := int64(1); seed <= 20; seed++ {
+				first := newStrategies()[name].Pick(testPool(candidates, load, seed), 3)
+				second := newStrategies()[name].Pick(testPool(candidates, load, seed), 3)
+
+				if !slices.Equal(first, second) {
+					t.Fatalf("seed %d: Pick() = %v, then %v", seed, first, second)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedPrefersLessLoaded(t *testing.T) {
+	strategy := newStrategies()[StrategyWeighted]
+	candidates := []string{"busy", "idle"}
+	load := map[string]int{"busy": 9, "idle": 0}
+
+	// вес idle в 10 раз больше, поэтому первым он выбирается в подавляющем большинстве подборов
+	idleFirst := 0
+	for seed := int64(1); seed <= 200; seed++ {
+		if strategy.Pick(testPool(candidates, load, seed), 1)[0] == "idle" {
+			idleFirst++
+		}
+	}
+
+	if idleFirst < 150 {
+		t.Errorf("idle picked first %d times of 200, want most", idleFirst)
+	}
+}
+
+func TestRoundRobinContinues(t *testing.T) {
+	strategy := newStrategies()[StrategyRoundRobin]
+	candidates := []string{"u3", "u1", "u2"}
+
+	steps := []struct {
+		candidates []string
+		count      int
+		want       []string
+	}{
+		{candidates: candidates, count: 2, want: []string{"u1", "u2"}},
+		{candidates: candidates, count: 2, want: []string{"u3", "u1"}},
+		// последний выбранный выбыл из пула: продолжаем со следующего по id
+		{candidates: []string{"u2", "u3"}, count: 1, want: []string{"u2"}},
+		{candidates: candidates, count: 1, want: []string{"u3"}},
+	}
+
+	for i, step := range steps {
+		got := strategy.Pick(testPool(step.candidates, nil, testSeed), step.count)
+		if !slices.Equal(got, step.want) {
+			t.Fatalf("step %d: Pick() = %v, want %v", i, got, step.want)
+		}
+	}
+
+	// очереди команд независимы
+	pool := testPool(candidates, nil, testSeed)
+	pool.TeamName = "frontend"
+	if got := strategy.Pick(pool, 1); !slices.Equal(got, []string{"u1"}) {
+		t.Errorf("Pick() for another team = %v, want [u1]", got)
+	}
+}
+
+func TestSeedSource(t *testing.T) {
+	seeds := func(s *seedSource, n int) []int64 {
+		result := make([]int64, 0, n)
+		for i := 0; i < n; i++ {
+			result = append(result, s.next())
+		}
+		return result
+	}
+
+	// источники с одинаковым сидом выдают одинаковые последовательности
+	want := seeds(newSeedSource(rand.NewSource(testSeed)), 3)
+	if got := seeds(newSeedSource(rand.NewSource(testSeed)), 3); !slices.Equal(got, want) {
+		t.Errorf("same source seeds = %v, want %v", got, want)
+	}
+
+	if want[0] == want[1] && want[1] == want[2] {
+		t.Errorf("seeds = %v, want different seeds for each assignment", want)
+	}
+
+	// копия выдает следующий сид исходного и не сдвигает его последовательность
+	source := newSeedSource(rand.NewSource(testSeed))
+	if got := source.fork().next(); got != want[0] {
+		t.Errorf("fork().next() = %d, want %d", got, want[0])
+	}
+	source.fork().next()
+
+	if got := seeds(source, 3); !slices.Equal(got, want) {
+		t.Errorf("seeds after fork = %v, want %v", got, want)
+	}
+}
+
+func TestNewStrategies(t *testing.T) {
+	strategies := newStrategies()
+
+	for _, name := range []string{StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted} {
+		strategy, ok := strategies[name]
+		if !ok {
+			t.Errorf("strategy %s is not registered", name)
+			continue
+		}
+
+		if strategy.Name() != name {
+			t.Errorf("strategies[%s].Name() = %s", name, strategy.Name())
+		}
+	}
+
+	if len(strategies) != 4 {
+		t.Errorf("len(newStrategies()) = %d, want 4", len(strategies))
+	}
+}
+
+func TestValidateStrategy(t *testing.T) {
+	strategies := newStrategies()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: StrategyRandom},
+		{name: StrategyRoundRobin},
+		{name: StrategyLeastLoaded},
+		{name: StrategyWeighted},
+		{name: "", wantErr: true},
+		{name: "fastest", wantErr: true},
+		{name: StrategyManual, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := validateStrategy(strategies, tt.name)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("validateStrategy(%q) = %v, want error: %v", tt.name, err, tt.wantErr)
+		}
+
+		if err != nil && !errors.Is(err, entity.ErrUnknownStrategy) {
+			t.Errorf("validateStrategy(%q) = %v, want ErrUnknownStrategy", tt.name, err)
+		}
+	}
+}
+
+func TestStrategyFor(t *testing.T) {
+	uc := &UseCase{strategies: newStrategies(), defaultStrategy: StrategyLeastLoaded}
+
+	tests := []struct {
+		teamStrategy string
+		want         string
+	}{
+		{teamStrategy: StrategyRandom, want: StrategyRandom},
+		{teamStrategy: "", want: StrategyLeastLoaded},
+		{teamStrategy: "removed", want: StrategyLeastLoaded},
+	}
+
+	for _, tt := range tests {
+		got := uc.strategyFor(&entity.Team{AssignmentStrategy: tt.teamStrategy}).Name()
+		if got != tt.want {
+			t.Errorf("strategyFor(%q) = %s, want %s", tt.teamStrategy, got, tt.want)
+		}
+	}
+}
+
+func TestCloneStrategies(t *testing.T) {
+	strategies := newStrategies()
+	pool := testPool([]string{"u1", "u2", "u3"}, nil, testSeed)
+
+	// выбор в копии не сдвигает очередь round_robin исходных стратегий
+	clone := cloneStrategies(strategies)
+	clone[StrategyRoundRobin].Pick(pool, 2)
+
+	if got := strategies[StrategyRoundRobin].Pick(pool, 1); !slices.Equal(got, []string{"u1"}) {
+		t.Errorf("Pick() after clone = %v, want [u1]", got)
+	}
+
+	// копия начинает с состояния исходных стратегий
+	clone = cloneStrategies(strategies)
+	if got := clone[StrategyRoundRobin].Pick(pool, 1); !slices.Equal(got, []string{"u2"}) {
+		t.Errorf("clone Pick() = %v, want [u2]", got)
+	}
+}
@@ -2,9 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"pr_reviewer_service/internal/config"
 	"pr_reviewer_service/internal/entity"
+	"strings"
 	"time"
 )
 
@@ -12,39 +15,129 @@ import (
 type RepositoryProvider interface {
 	CreateTeam(ctx context.Context, team entity.Team) error
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
+	UpdateTeamSettings(ctx context.Context, settings entity.TeamSettings) error
 	ChangeActivityUser(ctx context.Context, isActive bool, userID string) error
 	GetReviewFromUser(ctx context.Context, userID string) ([]entity.PullRequestShort, error)
+	GetTeamOpenReviewCounts(ctx context.Context, teamName string) (map[string]int, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
 	CreatePullRequest(ctx context.Context, pr entity.PullRequest) error
 	GetPR(ctx context.Context, pullRequestID string) (entity.PullRequest, error)
-	UpdatePRStatus(ctx context.Context, prID, newPrStatus string) error
-	MergePr(ctx context.Context, prID string) (*entity.PullRequest, error)
-	ReassignPrReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (entity.PullRequest, error)
+	ClosePr(ctx context.Context, prID string, from entity.PRStatus) error
+	OpenPr(ctx context.Context, prID string, from entity.PRStatus, reviewerIDs []string,
+		reasons map[string]entity.AssignmentReason) error
+	MergePr(ctx context.Context, prID string, override *entity.MergeOverride) (*entity.PullRequest, error)
+	GetMergeOverrides(ctx context.Context, prID string) ([]entity.MergeOverride, error)
+	ReassignPrReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string,
+		reason entity.AssignmentReason) (entity.PullRequest, error)
 	GetTeamByUserID(ctx context.Context, userID string) (string, error)
+	GetTeamsByUserID(ctx context.Context, userID string) ([]string, error)
+	GetUser(ctx context.Context, userID string) (*entity.User, error)
+	SetUserSeniority(ctx context.Context, userID, seniority string) error
+	SetUserAdmin(ctx context.Context, userID string, isAdmin bool) error
+	SetUserMaxOpenReviews(ctx context.Context, userID string, maxOpenReviews *int) error
+	CreateAbsence(ctx context.Context, absence entity.Absence) (int64, error)
+	GetAbsence(ctx context.Context, absenceID int64) (*entity.Absence, error)
+	GetUserAbsences(ctx context.Context, userID string) ([]entity.Absence, error)
+	UpdateAbsence(ctx context.Context, absence entity.Absence) error
+	DeleteAbsence(ctx context.Context, absenceID int64) error
+	GetTeamAbsences(ctx context.Context, teamName string, from time.Time) ([]entity.Absence, error)
+	AddUserTags(ctx context.Context, userID string, tags []string) error
+	RemoveUserTags(ctx context.Context, userID string, tags []string) error
+	GetUserTags(ctx context.Context, userID string) ([]string, error)
+	ReplaceCodeOwnerRules(ctx context.Context, rules []entity.CodeOwnerRule) error
+	GetCodeOwnerRules(ctx context.Context) ([]entity.CodeOwnerRule, error)
 	CheckTeam(ctx context.Context, teamName string) (bool, error)
 	CheckUser(ctx context.Context, userID string) (bool, error)
 	CheckPR(ctx context.Context, prID string) (bool, error)
+	GetUnderstaffedPRs(ctx context.Context, teamName string) ([]string, error)
+	AddPrReviewers(ctx context.Context, prID string, reviewerIDs []string, reasons map[string]entity.AssignmentReason) error
+	GetTeamsByFallback(ctx context.Context, fallbackTeamName string) ([]string, error)
+	RemovePrReviewer(ctx context.Context, prID, reviewerID string) error
+	SetReviewState(ctx context.Context, prID, reviewerID, state string) error
+	GetPRIDByNumber(ctx context.Context, repository string, number int) (string, error)
+	SaveRepository(ctx context.Context, repository entity.Repository) error
+	GetRepository(ctx context.Context, repositoryName string) (*entity.Repository, error)
+	AddTeamMember(ctx context.Context, teamName string, member entity.TeamMember) error
+	UpdateTeamMember(ctx context.Context, update entity.TeamMemberUpdate) error
+	RemoveTeamMember(ctx context.Context, teamName, userID string, handoffs []entity.ReviewHandoff) error
+	MoveUserTeam(ctx context.Context, userID, fromTeam, toTeam string, handoffs []entity.ReviewHandoff) error
 }
 
 // UseCaseInterface - интерфейс для usecase
 type UseCaseInterface interface {
 	CreateTeam(ctx context.Context, team entity.Team) (*entity.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*entity.Team, error)
-	ChangeActivityUser(ctx context.Context, user entity.User) (*entity.User, error)
-	GetReviewFromUser(ctx context.Context, userID string) ([]entity.PullRequestShort, error)
-	CreatePullRequest(ctx context.Context, pr entity.PullRequestShort) (*entity.PullRequest, error)
-	MergePr(ctx context.Context, prID string) (*entity.PullRequest, error)
-	ReassignPrReviewer(ctx context.Context, prID, oldReviewerID string) (*entity.PullRequest, string, error)
+	UpdateTeamSettings(ctx context.Context, settings entity.TeamSettings) (*entity.Team, error)
+	AddTeamMember(ctx context.Context, add entity.TeamMemberAdd) (*entity.TeamMemberResult, error)
+	UpdateTeamMember(ctx context.Context, update entity.TeamMemberUpdate) (*entity.TeamMemberResult, error)
+	RemoveTeamMember(ctx context.Context, remove entity.TeamMemberRemove) (*entity.TeamMemberResult, error)
+	ChangeActivityUser(ctx context.Context, user entity.UserActivity) (*entity.UserActivityResult, error)
+	MoveUserTeam(ctx context.Context, move entity.UserMove) (*entity.UserMoveResult, error)
+	GetReviewFromUser(ctx context.Context, userID, state string) ([]entity.PullRequestShort, error)
+	SubmitReview(ctx context.Context, review entity.ReviewSubmit) (*entity.PullRequest, error)
+	SetUserSeniority(ctx context.Context, user entity.UserSeniority) (*entity.UserSeniority, error)
+	SetUserAdmin(ctx context.Context, user entity.UserAdmin) (*entity.UserAdmin, error)
+	SetUserMaxOpenReviews(ctx context.Context, user entity.UserCapacity) (*entity.UserCapacity, error)
+	AddAbsence(ctx context.Context, absence entity.Absence) (*entity.Absence, error)
+	GetUserAbsences(ctx context.Context, userID string) ([]entity.Absence, error)
+	UpdateAbsence(ctx context.Context, absence entity.Absence) (*entity.Absence, error)
+	DeleteAbsence(ctx context.Context, absenceID int64) error
+	GetTeamAbsences(ctx context.Context, teamName string) ([]entity.Absence, error)
+	AddUserTags(ctx context.Context, userTags entity.UserTags) (*entity.UserTags, error)
+	RemoveUserTags(ctx context.Context, userTags entity.UserTags) (*entity.UserTags, error)
+	CreatePullRequest(ctx context.Context, pr entity.PullRequestCreate) (*entity.PullRequest, error)
+	PreviewPullRequest(ctx context.Context, pr entity.PullRequestCreate) (*entity.AssignmentPreview, error)
+	GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error)
+	AddReviewer(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error)
+	RemoveReviewer(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error)
+	MergePr(ctx context.Context, req entity.MergeRequest) (*entity.PullRequest, error)
+	MarkReady(ctx context.Context, prID string) (*entity.PullRequest, error)
+	ClosePr(ctx context.Context, prID string) (*entity.PullRequest, error)
+	ReopenPr(ctx context.Context, prID string) (*entity.PullRequest, error)
+	GetMergeOverrides(ctx context.Context, prID string) ([]entity.MergeOverride, error)
+	ReassignPrReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (*entity.PullRequest, string, error)
+	ImportCodeOwners(ctx context.Context, content string) (*entity.CodeOwnersImport, error)
+	GetCodeOwners(ctx context.Context) ([]entity.CodeOwnerRule, error)
+	BackfillTeam(ctx context.Context, teamName string) (*entity.BackfillResult, error)
+	ResolvePullRequestID(ctx context.Context, ref entity.PRRef) (string, error)
+	SaveRepository(ctx context.Context, repository entity.Repository) (*entity.Repository, error)
+	GetRepository(ctx context.Context, repositoryName string) (*entity.Repository, error)
 }
 
 // UseCase - бизнес логика
 type UseCase struct {
-	repo RepositoryProvider
+	repo            RepositoryProvider
+	strategies      map[string]AssignmentStrategy
+	defaultStrategy string
+	seeds           *seedSource
+}
+
+// New - конструктор бизнес логики, источник случайности задается сидом из конфига
+func New(repo RepositoryProvider, cfg *config.Config) (UseCaseInterface, error) {
+	seed := cfg.AssignmentSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return NewWithSource(repo, cfg, rand.NewSource(seed))
 }
 
-// New - конструктор бизнес логики
-func New(repo RepositoryProvider) UseCaseInterface {
-	useCase := UseCase{repo: repo}
-	return NewObs(useCase)
+// NewWithSource - конструктор бизнес логики с заданным источником случайности для подбора ревьюверов
+func NewWithSource(repo RepositoryProvider, cfg *config.Config, source rand.Source) (UseCaseInterface, error) {
+	strategies := newStrategies()
+
+	if err := validateStrategy(strategies, cfg.AssignmentStrategy); err != nil {
+		return nil, err
+	}
+
+	useCase := UseCase{
+		repo:            repo,
+		strategies:      strategies,
+		defaultStrategy: cfg.AssignmentStrategy,
+		seeds:           newSeedSource(source),
+	}
+
+	return NewObs(useCase), nil
 }
 
 // CreateTeam - создание команды
@@ -57,6 +150,44 @@ func (uc *UseCase) CreateTeam(ctx context.Context, team entity.Team) (*entity.Te
 		return nil, fmt.Errorf("team name is empty")
 	}
 
+	if team.AssignmentStrategy != "" {
+		if err := validateStrategy(uc.strategies, team.AssignmentStrategy); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range team.Members {
+		if err := normalizeMember(&team.Members[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	capacity, err := normalizeCapacity(team.DefaultMaxOpenReviews)
+	if err != nil {
+		return nil, err
+	}
+	team.DefaultMaxOpenReviews = capacity
+
+	if team.ReviewersCount < 0 {
+		return nil, entity.ErrInvalidReviewers
+	}
+
+	if team.MergePolicy.MinApprovals < 0 {
+		return nil, entity.ErrInvalidMergePolicy
+	}
+
+	if team.ReviewersCount == 0 {
+		team.ReviewersCount = entity.DefaultReviewersCount
+	}
+
+	if err := validateSizeTiers(team.SizeTiers); err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateFallbackTeams(ctx, team.TeamName, team.FallbackTeams); err != nil {
+		return nil, err
+	}
+
 	// проверяем существование команды
 	existTeam, err := uc.repo.CheckTeam(ctx, team.TeamName)
 	if err != nil {
@@ -72,7 +203,8 @@ func (uc *UseCase) CreateTeam(ctx context.Context, team entity.Team) (*entity.Te
 		return nil, err
 	}
 
-	return &team, nil
+	// участники других команд сохраняют прежние данные, поэтому возвращается сохраненная команда
+	return uc.repo.GetTeam(ctx, team.TeamName)
 }
 
 // GetTeam - получить название команды и участников
@@ -93,8 +225,87 @@ func (uc *UseCase) GetTeam(ctx context.Context, teamName string) (*entity.Team,
 	return team, nil
 }
 
-// ChangeActivityUser - изменение активности пользователя
-func (uc *UseCase) ChangeActivityUser(ctx context.Context, user entity.User) (*entity.User, error) {
+// UpdateTeamSettings - изменить настройки команды
+func (uc *UseCase) UpdateTeamSettings(ctx context.Context, settings entity.TeamSettings) (*entity.Team, error) {
+	if settings.TeamName == "" {
+		return nil, fmt.Errorf("team name is empty")
+	}
+
+	// пустая строка сбрасывает стратегию на стратегию из конфига
+	if settings.AssignmentStrategy != nil && *settings.AssignmentStrategy != "" {
+		if err := validateStrategy(uc.strategies, *settings.AssignmentStrategy); err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.ReviewersCount != nil && *settings.ReviewersCount <= 0 {
+		return nil, entity.ErrInvalidReviewers
+	}
+
+	if settings.DefaultMaxOpenReviews != nil && *settings.DefaultMaxOpenReviews < 0 {
+		return nil, entity.ErrInvalidCapacity
+	}
+
+	if settings.MergePolicy != nil && settings.MergePolicy.MinApprovals != nil && *settings.MergePolicy.MinApprovals < 0 {
+		return nil, entity.ErrInvalidMergePolicy
+	}
+
+	if err := validateSizeTiers(settings.SizeTiers); err != nil {
+		return nil, err
+	}
+
+	existTeam, err := uc.repo.CheckTeam(ctx, settings.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existTeam {
+		return nil, entity.ErrNotFound
+	}
+
+	if err := uc.validateFallbackTeams(ctx, settings.TeamName, settings.FallbackTeams); err != nil {
+		return nil, err
+	}
+
+	err = uc.repo.UpdateTeamSettings(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.GetTeam(ctx, settings.TeamName)
+}
+
+// validateFallbackTeams - резервные команды должны существовать, не повторяться и не совпадать с самой командой
+func (uc *UseCase) validateFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	seen := make(map[string]struct{}, len(fallbackTeams))
+
+	for _, fallbackName := range fallbackTeams {
+		if fallbackName == teamName {
+			return fmt.Errorf("%w: team cannot be its own fallback", entity.ErrInvalidFallback)
+		}
+
+		if _, ok := seen[fallbackName]; ok {
+			return fmt.Errorf("%w: duplicate %s", entity.ErrInvalidFallback, fallbackName)
+		}
+		seen[fallbackName] = struct{}{}
+
+		exist, err := uc.repo.CheckTeam(ctx, fallbackName)
+		if err != nil {
+			return err
+		}
+
+		if !exist {
+			return fmt.Errorf("%w: team %s not found", entity.ErrInvalidFallback, fallbackName)
+		}
+	}
+
+	return nil
+}
+
+// ChangeActivityUser - изменение активности пользователя.
+// При деактивации в режиме передачи ревью открытые ревью пользователя переназначаются по правилам ReassignPrReviewer.
+// Если после сохранения активности что-то не удалось, возвращается результат вместе с ошибкой
+func (uc *UseCase) ChangeActivityUser(ctx context.Context, user entity.UserActivity) (*entity.UserActivityResult, error) {
 	if user.UserID == "" {
 		return nil, fmt.Errorf("userID is empty")
 	}
@@ -113,26 +324,343 @@ func (uc *UseCase) ChangeActivityUser(ctx context.Context, user entity.User) (*e
 		return nil, err
 	}
 
-	return &user, nil
+	result := &entity.UserActivityResult{
+		UserID:   user.UserID,
+		IsActive: user.IsActive,
+	}
+
+	// активность уже сохранена: при ошибке добора результат возвращается вместе с ошибкой
+	if user.IsActive {
+		result.Backfilled, err = uc.backfillForUser(ctx, user.UserID)
+		if err != nil {
+			return result, fmt.Errorf("user activated, backfill failed: %w", err)
+		}
+
+		return result, nil
+	}
+
+	reassign := false
+	if user.ReassignReviews != nil {
+		reassign = *user.ReassignReviews
+	} else {
+		// достаточно, чтобы передачу ревью требовала хотя бы одна из команд пользователя
+		teams, err := uc.repo.GetTeamsByUserID(ctx, user.UserID)
+		if err != nil {
+			return result, fmt.Errorf("user deactivated, reassign failed: %w", err)
+		}
+
+		for _, teamName := range teams {
+			team, err := uc.repo.GetTeam(ctx, teamName)
+			if err != nil {
+				return result, fmt.Errorf("user deactivated, reassign failed: %w", err)
+			}
+
+			if team.ReassignOnDeactivate {
+				reassign = true
+				break
+			}
+		}
+	}
+
+	if !reassign {
+		return result, nil
+	}
+
+	result.Reassigned, result.NotReassigned, err = uc.reassignOpenReviews(ctx, user.UserID, nil)
+	if err != nil {
+		return result, fmt.Errorf("user deactivated, reassign failed: %w", err)
+	}
+
+	return result, nil
+}
+
+// reassignOpenReviews - переназначить открытые ревью пользователя; filter отбирает pr, nil - все открытые.
+// Ошибка по отдельному pr не прерывает остальные, а попадает в список непереназначенных
+func (uc *UseCase) reassignOpenReviews(ctx context.Context, userID string,
+	filter func(pr entity.PullRequestShort) bool) ([]entity.ReassignedReview, []entity.FailedReassign, error) {
+	reviews, err := uc.repo.GetReviewFromUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reassigned := []entity.ReassignedReview{}
+	failed := []entity.FailedReassign{}
+
+	for _, review := range reviews {
+		if review.Status != entity.PRStatusOpen {
+			continue
+		}
+
+		if filter != nil && !filter(review) {
+			continue
+		}
+
+		_, newReviewerID, err := uc.ReassignPrReviewer(ctx, review.PullRequestID, userID, "")
+		if err != nil {
+			failed = append(failed, entity.FailedReassign{
+				PullRequestID: review.PullRequestID,
+				Reason:        err.Error(),
+			})
+			continue
+		}
+
+		reassigned = append(reassigned, entity.ReassignedReview{
+			PullRequestID: review.PullRequestID,
+			OldReviewerID: userID,
+			NewReviewerID: newReviewerID,
+		})
+	}
+
+	return reassigned, failed, nil
 }
 
-// GetReviewFromUser - получить pr для пользователя
-func (uc *UseCase) GetReviewFromUser(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
+// GetReviewFromUser - получить pr для пользователя, state фильтрует по состоянию его ревью
+func (uc *UseCase) GetReviewFromUser(ctx context.Context, userID, state string) ([]entity.PullRequestShort, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("username is empty")
 	}
 
-	return uc.repo.GetReviewFromUser(ctx, userID)
+	if state != "" {
+		if err := validateReviewState(state); err != nil {
+			return nil, err
+		}
+	}
+
+	reviews, err := uc.repo.GetReviewFromUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if state == "" {
+		return reviews, nil
+	}
+
+	filtered := []entity.PullRequestShort{}
+	for _, review := range reviews {
+		if review.ReviewState == state {
+			filtered = append(filtered, review)
+		}
+	}
+
+	return filtered, nil
+}
+
+// SetUserSeniority - изменить уровень пользователя
+func (uc *UseCase) SetUserSeniority(ctx context.Context, user entity.UserSeniority) (*entity.UserSeniority, error) {
+	if user.UserID == "" {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	if err := validateSeniority(user.Seniority); err != nil {
+		return nil, err
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	err = uc.repo.SetUserSeniority(ctx, user.UserID, user.Seniority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetUserAdmin - выдать или забрать права администратора
+func (uc *UseCase) SetUserAdmin(ctx context.Context, user entity.UserAdmin) (*entity.UserAdmin, error) {
+	if user.UserID == "" {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	err = uc.repo.SetUserAdmin(ctx, user.UserID, user.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// validateSeniority - проверить уровень пользователя
+func validateSeniority(seniority string) error {
+	switch seniority {
+	case entity.SeniorityJunior, entity.SeniorityMiddle, entity.SenioritySenior:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", entity.ErrInvalidSeniority, seniority)
+	}
+}
+
+// SetUserMaxOpenReviews - изменить лимит открытых ревью пользователя
+func (uc *UseCase) SetUserMaxOpenReviews(ctx context.Context, user entity.UserCapacity) (*entity.UserCapacity, error) {
+	if user.UserID == "" {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	capacity, err := normalizeCapacity(user.MaxOpenReviews)
+	if err != nil {
+		return nil, err
+	}
+	user.MaxOpenReviews = capacity
+
+	existUser, err := uc.repo.CheckUser(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	err = uc.repo.SetUserMaxOpenReviews(ctx, user.UserID, user.MaxOpenReviews)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// normalizeMember - привести теги, уровень и лимит участника команды к сохраняемому виду
+func normalizeMember(member *entity.TeamMember) error {
+	member.Tags = normalizeTags(member.Tags)
+
+	if member.Seniority == "" {
+		member.Seniority = entity.SeniorityMiddle
+	}
+
+	if err := validateSeniority(member.Seniority); err != nil {
+		return err
+	}
+
+	capacity, err := normalizeCapacity(member.MaxOpenReviews)
+	if err != nil {
+		return err
+	}
+	member.MaxOpenReviews = capacity
+
+	return nil
+}
+
+// normalizeCapacity - лимит открытых ревью не может быть отрицательным, 0 означает отсутствие лимита
+func normalizeCapacity(capacity *int) (*int, error) {
+	if capacity == nil || *capacity == 0 {
+		return nil, nil
+	}
+
+	if *capacity < 0 {
+		return nil, entity.ErrInvalidCapacity
+	}
+
+	return capacity, nil
+}
+
+// AddUserTags - добавить пользователю теги экспертизы
+func (uc *UseCase) AddUserTags(ctx context.Context, userTags entity.UserTags) (*entity.UserTags, error) {
+	return uc.changeUserTags(ctx, userTags, uc.repo.AddUserTags)
+}
+
+// RemoveUserTags - убрать у пользователя теги экспертизы
+func (uc *UseCase) RemoveUserTags(ctx context.Context, userTags entity.UserTags) (*entity.UserTags, error) {
+	return uc.changeUserTags(ctx, userTags, uc.repo.RemoveUserTags)
+}
+
+// changeUserTags - общая часть добавления и удаления тегов, возвращает итоговый набор тегов
+func (uc *UseCase) changeUserTags(ctx context.Context, userTags entity.UserTags,
+	change func(ctx context.Context, userID string, tags []string) error) (*entity.UserTags, error) {
+	if userTags.UserID == "" {
+		return nil, fmt.Errorf("userID is empty")
+	}
+
+	tags := normalizeTags(userTags.Tags)
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("tags is empty")
+	}
+
+	existUser, err := uc.repo.CheckUser(ctx, userTags.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existUser {
+		return nil, entity.ErrNotFound
+	}
+
+	err = change(ctx, userTags.UserID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.repo.GetUserTags(ctx, userTags.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.UserTags{UserID: userTags.UserID, Tags: current}, nil
+}
+
+// normalizeTags - привести теги и метки к нижнему регистру, убрать пустые и повторы
+func normalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]struct{}, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	return result
 }
 
 // CreatePullRequest - создать pr
-func (uc *UseCase) CreatePullRequest(ctx context.Context, pr entity.PullRequestShort) (*entity.PullRequest, error) {
+func (uc *UseCase) CreatePullRequest(ctx context.Context, pr entity.PullRequestCreate) (*entity.PullRequest, error) {
 	var fullPr entity.PullRequest
 
+	if err := validatePRMetadata(pr.PRMetadata); err != nil {
+		return nil, err
+	}
+
+	// без явного id pr идентифицируется репозиторием и номером
+	if pr.PullRequestID == "" && pr.Number != nil {
+		pr.PullRequestID = fmt.Sprintf("%s#%d", pr.Repository, *pr.Number)
+	}
+
 	if pr.PullRequestID == "" || pr.PullRequestName == "" {
 		return nil, fmt.Errorf("pull request id or pull request name is empty")
 	}
 
+	if pr.ReviewersCount != nil && *pr.ReviewersCount <= 0 {
+		return nil, entity.ErrInvalidReviewers
+	}
+
+	pr.Labels = normalizeTags(pr.Labels)
+
+	if pr.ChangedFilesCount == nil && len(pr.ChangedFiles) > 0 {
+		count := len(pr.ChangedFiles)
+		pr.ChangedFilesCount = &count
+	}
+
 	// проверяем существование такого pr
 	existPR, err := uc.repo.CheckPR(ctx, pr.PullRequestID)
 	if err != nil {
@@ -143,6 +671,17 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr entity.PullRequestS
 		return nil, entity.ErrPrExists
 	}
 
+	if pr.Number != nil {
+		_, err = uc.repo.GetPRIDByNumber(ctx, pr.Repository, *pr.Number)
+		if err == nil {
+			return nil, entity.ErrPrExists
+		}
+
+		if !errors.Is(err, entity.ErrNotFound) {
+			return nil, err
+		}
+	}
+
 	// проверяем существование пользователя
 	existUser, err := uc.repo.CheckUser(ctx, pr.AuthorID)
 	if err != nil {
@@ -153,23 +692,48 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr entity.PullRequestS
 		return nil, entity.ErrNotFound
 	}
 
-	// смотрим в какой команде пользователь
-	teamName, err := uc.repo.GetTeamByUserID(ctx, pr.AuthorID)
+	// смотрим, для какой команды pr
+	teamName, err := uc.targetTeam(ctx, pr)
 	if err != nil {
 		return nil, err
 	}
+	pr.TeamName = teamName
 
-	reviewers, err := uc.generateReviewers(ctx, teamName, pr.AuthorID)
+	// размер pr выбирает количество ревьюверов, если оно не задано явно
+	sizeTier, err := uc.applySizeTier(ctx, teamName, &pr)
 	if err != nil {
 		return nil, err
 	}
 
+	// черновику ревьюверы назначаются, когда он будет готов к ревью
+	fullPr.Status = entity.PRStatusDraft
+	assigned := newAssignment(0)
+	if !pr.Draft {
+		fullPr.Status = entity.PRStatusOpen
+
+		assigned, err = uc.generateReviewers(ctx, teamName, pr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// заполняем структуры pr-а
 	fullPr.PullRequestID = pr.PullRequestID
 	fullPr.PullRequestName = pr.PullRequestName
 	fullPr.AuthorID = pr.AuthorID
-	fullPr.Status = "OPEN"
-	fullPr.AssignedReviewers = reviewers
+	fullPr.AssignedReviewers = assigned.reviewers
+	fullPr.Labels = pr.Labels
+	if pr.ReviewersCount != nil {
+		fullPr.ReviewersCount = *pr.ReviewersCount
+	}
+	fullPr.SizeTier = sizeTier
+	if len(assigned.fallback) > 0 {
+		fullPr.FallbackReviewers = assigned.fallback
+	}
+	fullPr.AssignmentReason = assigned.reasons
+	fullPr.ChangedFiles = pr.ChangedFiles
+	fullPr.TeamName = pr.TeamName
+	fullPr.PRMetadata = pr.PRMetadata
 	fullPr.CreatedAt = time.Now()
 
 	err = uc.repo.CreatePullRequest(ctx, fullPr)
@@ -180,39 +744,48 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr entity.PullRequestS
 	return &fullPr, nil
 }
 
-// generateReviewers - генерация ревьюеров на pr
-func (uc *UseCase) generateReviewers(ctx context.Context, teamName, authorID string) ([]string, error) {
-	var candidates []string
-
-	team, err := uc.repo.GetTeam(ctx, teamName)
-	if err != nil {
-		return nil, err
+// validatePRMetadata - номер pr задается вместе с репозиторием, размеры pr не могут быть отрицательными
+func validatePRMetadata(meta entity.PRMetadata) error {
+	if meta.Number != nil && (*meta.Number <= 0 || meta.Repository == "") {
+		return entity.ErrInvalidPRRef
 	}
 
-	for _, member := range team.Members {
-		if member.IsActive && member.UserID != authorID {
-			candidates = append(candidates, member.UserID)
+	for _, size := range []*int{meta.Additions, meta.Deletions, meta.ChangedFilesCount} {
+		if size != nil && *size < 0 {
+			return entity.ErrInvalidPRMetadata
 		}
 	}
 
-	// если кандидатов нет возвращаем пустой список
-	if len(candidates) == 0 {
-		return candidates, nil
+	return nil
+}
+
+// GetPullRequest - получить pr вместе с объяснением выбора ревьюверов
+func (uc *UseCase) GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	if prID == "" {
+		return nil, fmt.Errorf("prID is empty")
+	}
+
+	existPR, err := uc.repo.CheckPR(ctx, prID)
+	if err != nil {
+		return nil, err
 	}
 
-	rand.Shuffle(len(candidates), func(i, j int) {
-		candidates[i], candidates[j] = candidates[j], candidates[i]
-	})
+	if !existPR {
+		return nil, entity.ErrNotFound
+	}
 
-	if len(candidates) > 2 {
-		candidates = candidates[:2]
+	pr, err := uc.repo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, err
 	}
 
-	return candidates, nil
+	return &pr, nil
 }
 
-// MergePr - замержить pr
-func (uc *UseCase) MergePr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+// MergePr - замержить pr, если выполнены условия политики мержа команды автора.
+// С флагом override pr мержится несмотря на невыполненные условия, обход записывается в аудит
+func (uc *UseCase) MergePr(ctx context.Context, req entity.MergeRequest) (*entity.PullRequest, error) {
+	prID := req.PullRequestID
 	if prID == "" {
 		return nil, fmt.Errorf("prID is empty")
 	}
@@ -227,7 +800,38 @@ func (uc *UseCase) MergePr(ctx context.Context, prID string) (*entity.PullReques
 		return nil, entity.ErrNotFound
 	}
 
-	mergedPR, err := uc.repo.MergePr(ctx, prID)
+	pr, err := uc.repo.GetPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	// повторный мерж ничего не меняет
+	if pr.Status == entity.PRStatusMerged {
+		return &pr, nil
+	}
+
+	if !pr.Status.CanTransitionTo(entity.PRStatusMerged) {
+		return nil, fmt.Errorf("%w: %s -> %s", entity.ErrInvalidTransition, pr.Status, entity.PRStatusMerged)
+	}
+
+	unmet, err := uc.unmetMergeConditions(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	var override *entity.MergeOverride
+	if len(unmet) > 0 {
+		if !req.Override {
+			return nil, &entity.MergeBlockedError{Unmet: unmet}
+		}
+
+		override, err = uc.newMergeOverride(ctx, req, unmet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mergedPR, err := uc.repo.MergePr(ctx, prID, override)
 	if err != nil {
 		return nil, err
 	}
@@ -235,8 +839,9 @@ func (uc *UseCase) MergePr(ctx context.Context, prID string) (*entity.PullReques
 	return mergedPR, nil
 }
 
-// ReassignPrReviewer - заменить ревьювера
-func (uc *UseCase) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID string) (*entity.PullRequest, string, error) {
+// ReassignPrReviewer - заменить ревьювера; newReviewerID выбирает замену явно, пусто - подобрать
+func (uc *UseCase) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID,
+	newReviewerID string) (*entity.PullRequest, string, error) {
 	if prID == "" {
 		return nil, "", fmt.Errorf("prID is empty")
 	}
@@ -244,24 +849,44 @@ func (uc *UseCase) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID s
 		return nil, "", fmt.Errorf("oldReviewerID is empty")
 	}
 
+	newReviewerID, reason, err := uc.planReassign(ctx, prID, oldReviewerID, newReviewerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Обновляем PR в репозитории
+	pr, err := uc.repo.ReassignPrReviewer(ctx, prID, oldReviewerID, newReviewerID, reason)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &pr, newReviewerID, nil
+}
+
+// planReassign - проверить замену ревьювера на pr и выбрать нового, ничего не меняя.
+// Пустой newReviewerID - подобрать замену из команды старого ревьювера
+func (uc *UseCase) planReassign(ctx context.Context, prID, oldReviewerID,
+	newReviewerID string) (string, entity.AssignmentReason, error) {
+	var reason entity.AssignmentReason
+
 	// Проверка, существует ли PR
 	existPR, err := uc.repo.CheckPR(ctx, prID)
 	if err != nil {
-		return nil, "", err
+		return "", reason, err
 	}
 	if !existPR {
-		return nil, "", entity.ErrNotFound
+		return "", reason, entity.ErrNotFound
 	}
 
 	// Получаем PR для проверки статуса и ревьюверов
 	checkPr, err := uc.repo.GetPR(ctx, prID)
 	if err != nil {
-		return nil, "", err
+		return "", reason, err
 	}
 
 	// Проверка на merge pr-а
-	if checkPr.Status != "OPEN" {
-		return nil, "", entity.ErrPrMerged
+	if err := requireOpen(checkPr); err != nil {
+		return "", reason, err
 	}
 
 	// Проверка, что oldReviewerID действительно назначен на этот PR
@@ -273,22 +898,27 @@ func (uc *UseCase) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID s
 		}
 	}
 	if !found {
-		return nil, "", fmt.Errorf("reviewer is not assigned to this PR")
+		return "", reason, fmt.Errorf("%w: %s", entity.ErrNotAssigned, oldReviewerID)
 	}
 
 	// Проверка, существует ли старый ревьювер
 	existUser, err := uc.repo.CheckUser(ctx, oldReviewerID)
 	if err != nil {
-		return nil, "", err
+		return "", reason, err
 	}
 	if !existUser {
-		return nil, "", entity.ErrNotFound
+		return "", reason, entity.ErrNotFound
 	}
 
-	// Берем команду старого ревьювера
-	teamName, err := uc.repo.GetTeamByUserID(ctx, oldReviewerID)
+	// Берем команду pr и команду, из которой старый ревьювер
+	prTeam, err := uc.prTeam(ctx, checkPr)
 	if err != nil {
-		return nil, "", err
+		return "", reason, err
+	}
+
+	teamName, err := uc.reviewerTeam(ctx, oldReviewerID, prTeam)
+	if err != nil {
+		return "", reason, err
 	}
 
 	// Исключаем: старого ревьювера, автора PR и всех уже назначенных ревьюверов
@@ -300,45 +930,36 @@ func (uc *UseCase) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID s
 		}
 	}
 
-	// Генерируем нового ревьювера
-	newReviewerID, err := uc.selectNewReviewer(ctx, teamName, excludeIDs...)
-	if err != nil {
-		return nil, "", err
-	}
-
-	// Обновляем PR в репозитории
-	pr, err := uc.repo.ReassignPrReviewer(ctx, prID, oldReviewerID, newReviewerID)
-	if err != nil {
-		return nil, "", err
+	// Если старый ревьювер был единственным senior, замена тоже должна быть senior
+	var remaining []string
+	for _, reviewerID := range checkPr.AssignedReviewers {
+		if reviewerID != oldReviewerID {
+			remaining = append(remaining, reviewerID)
+		}
 	}
 
-	return &pr, newReviewerID, nil
-}
-
-// selectNewReviewer - выбрать нового ревьера, исключая указанные ID
-func (uc *UseCase) selectNewReviewer(ctx context.Context, teamName string, excludeIDs ...string) (string, error) {
-	team, err := uc.GetTeam(ctx, teamName)
+	onlySenior, err := uc.needsSenior(ctx, prTeam, remaining)
 	if err != nil {
-		return "", err
+		return "", reason, err
 	}
 
-	excludeMap := make(map[string]struct{})
-	for _, id := range excludeIDs {
-		excludeMap[id] = struct{}{}
+	req := assignRequest{
+		count:      1,
+		authorID:   checkPr.AuthorID,
+		exclude:    excludeIDs,
+		labels:     checkPr.Labels,
+		onlySenior: onlySenior,
 	}
 
-	var candidates []string
-	for _, m := range team.Members {
-		if m.IsActive {
-			if _, excluded := excludeMap[m.UserID]; !excluded {
-				candidates = append(candidates, m.UserID)
-			}
-		}
+	// Проверяем выбранного вызывающим ревьювера, либо генерируем нового
+	if newReviewerID != "" {
+		reason, err = uc.checkNewReviewer(ctx, teamName, newReviewerID, req)
+	} else {
+		newReviewerID, reason, err = uc.selectNewReviewer(ctx, teamName, req)
 	}
-
-	if len(candidates) == 0 {
-		return "", fmt.Errorf("no active replacement candidate in team")
+	if err != nil {
+		return "", reason, err
 	}
 
-	return candidates[rand.Intn(len(candidates))], nil
+	return newReviewerID, reason, nil
 }
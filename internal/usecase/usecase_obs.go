@@ -74,8 +74,32 @@ func (uc *UseCaseObs) GetTeam(ctx context.Context, teamName string) (*entity.Tea
 	return resp, err
 }
 
+// UpdateTeamSettings - метрики
+func (uc *UseCaseObs) UpdateTeamSettings(ctx context.Context, settings entity.TeamSettings) (*entity.Team, error) {
+	const methodName = "update_team_settings"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.UpdateTeamSettings(ctx, settings)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.UpdateTeamSettings")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
 // ChangeActivityUser - метрики
-func (uc *UseCaseObs) ChangeActivityUser(ctx context.Context, user entity.User) (*entity.User, error) {
+func (uc *UseCaseObs) ChangeActivityUser(ctx context.Context, user entity.UserActivity) (*entity.UserActivityResult, error) {
 	const methodName = "change_activity_user"
 
 	tracer := otel.Tracer(nameTracer)
@@ -99,7 +123,7 @@ func (uc *UseCaseObs) ChangeActivityUser(ctx context.Context, user entity.User)
 }
 
 // GetReviewFromUser  - метрики
-func (uc *UseCaseObs) GetReviewFromUser(ctx context.Context, userID string) ([]entity.PullRequestShort, error) {
+func (uc *UseCaseObs) GetReviewFromUser(ctx context.Context, userID, state string) ([]entity.PullRequestShort, error) {
 	const methodName = "get_review_from_user"
 
 	tracer := otel.Tracer(nameTracer)
@@ -108,7 +132,7 @@ func (uc *UseCaseObs) GetReviewFromUser(ctx context.Context, userID string) ([]e
 
 	startTime := time.Now()
 
-	resp, err := uc.UseCase.GetReviewFromUser(ctx, userID)
+	resp, err := uc.UseCase.GetReviewFromUser(ctx, userID, state)
 	if err != nil {
 		uc.metrics.HitError(methodName)
 		span.RecordError(err)
@@ -122,8 +146,248 @@ func (uc *UseCaseObs) GetReviewFromUser(ctx context.Context, userID string) ([]e
 	return resp, err
 }
 
+// SetUserSeniority - метрики
+func (uc *UseCaseObs) SetUserSeniority(ctx context.Context, user entity.UserSeniority) (*entity.UserSeniority, error) {
+	const methodName = "set_user_seniority"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.SetUserSeniority(ctx, user)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.SetUserSeniority")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// SetUserAdmin - метрики
+func (uc *UseCaseObs) SetUserAdmin(ctx context.Context, user entity.UserAdmin) (*entity.UserAdmin, error) {
+	const methodName = "set_user_admin"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.SetUserAdmin(ctx, user)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.SetUserAdmin")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// SetUserMaxOpenReviews - метрики
+func (uc *UseCaseObs) SetUserMaxOpenReviews(ctx context.Context, user entity.UserCapacity) (*entity.UserCapacity, error) {
+	const methodName = "set_user_max_open_reviews"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.SetUserMaxOpenReviews(ctx, user)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.SetUserMaxOpenReviews")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// AddAbsence - метрики
+func (uc *UseCaseObs) AddAbsence(ctx context.Context, absence entity.Absence) (*entity.Absence, error) {
+	const methodName = "add_absence"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.AddAbsence(ctx, absence)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.AddAbsence")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// GetUserAbsences - метрики
+func (uc *UseCaseObs) GetUserAbsences(ctx context.Context, userID string) ([]entity.Absence, error) {
+	const methodName = "get_user_absences"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.GetUserAbsences(ctx, userID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.GetUserAbsences")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// UpdateAbsence - метрики
+func (uc *UseCaseObs) UpdateAbsence(ctx context.Context, absence entity.Absence) (*entity.Absence, error) {
+	const methodName = "update_absence"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.UpdateAbsence(ctx, absence)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.UpdateAbsence")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// DeleteAbsence - метрики
+func (uc *UseCaseObs) DeleteAbsence(ctx context.Context, absenceID int64) error {
+	const methodName = "delete_absence"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	err := uc.UseCase.DeleteAbsence(ctx, absenceID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.DeleteAbsence")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return err
+}
+
+// GetTeamAbsences - метрики
+func (uc *UseCaseObs) GetTeamAbsences(ctx context.Context, teamName string) ([]entity.Absence, error) {
+	const methodName = "get_team_absences"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.GetTeamAbsences(ctx, teamName)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.GetTeamAbsences")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// AddUserTags - метрики
+func (uc *UseCaseObs) AddUserTags(ctx context.Context, userTags entity.UserTags) (*entity.UserTags, error) {
+	const methodName = "add_user_tags"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.AddUserTags(ctx, userTags)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.AddUserTags")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// RemoveUserTags - метрики
+func (uc *UseCaseObs) RemoveUserTags(ctx context.Context, userTags entity.UserTags) (*entity.UserTags, error) {
+	const methodName = "remove_user_tags"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.RemoveUserTags(ctx, userTags)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.RemoveUserTags")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
 // CreatePullRequest - метрики
-func (uc *UseCaseObs) CreatePullRequest(ctx context.Context, pr entity.PullRequestShort) (*entity.PullRequest, error) {
+func (uc *UseCaseObs) CreatePullRequest(ctx context.Context, pr entity.PullRequestCreate) (*entity.PullRequest, error) {
 	const methodName = "create_pull_request"
 
 	tracer := otel.Tracer(nameTracer)
@@ -147,7 +411,7 @@ func (uc *UseCaseObs) CreatePullRequest(ctx context.Context, pr entity.PullReque
 }
 
 // MergePr - метрики
-func (uc *UseCaseObs) MergePr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+func (uc *UseCaseObs) MergePr(ctx context.Context, req entity.MergeRequest) (*entity.PullRequest, error) {
 	const methodName = "merge_pr"
 
 	tracer := otel.Tracer(nameTracer)
@@ -156,7 +420,7 @@ func (uc *UseCaseObs) MergePr(ctx context.Context, prID string) (*entity.PullReq
 
 	startTime := time.Now()
 
-	resp, err := uc.UseCase.MergePr(ctx, prID)
+	resp, err := uc.UseCase.MergePr(ctx, req)
 	if err != nil {
 		uc.metrics.HitError(methodName)
 		span.RecordError(err)
@@ -171,7 +435,8 @@ func (uc *UseCaseObs) MergePr(ctx context.Context, prID string) (*entity.PullReq
 }
 
 // ReassignPrReviewer - метрики
-func (uc *UseCaseObs) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID string) (*entity.PullRequest, string, error) {
+func (uc *UseCaseObs) ReassignPrReviewer(ctx context.Context, prID, oldReviewerID,
+	newReviewerID string) (*entity.PullRequest, string, error) {
 	const methodName = "reassign_pr_reviewer"
 
 	tracer := otel.Tracer(nameTracer)
@@ -180,7 +445,7 @@ func (uc *UseCaseObs) ReassignPrReviewer(ctx context.Context, prID, oldReviewerI
 
 	startTime := time.Now()
 
-	resp1, resp2, err := uc.UseCase.ReassignPrReviewer(ctx, prID, oldReviewerID)
+	resp1, resp2, err := uc.UseCase.ReassignPrReviewer(ctx, prID, oldReviewerID, newReviewerID)
 	if err != nil {
 		uc.metrics.HitError(methodName)
 		span.RecordError(err)
@@ -193,3 +458,459 @@ func (uc *UseCaseObs) ReassignPrReviewer(ctx context.Context, prID, oldReviewerI
 
 	return resp1, resp2, err
 }
+
+// ImportCodeOwners - метрики
+func (uc *UseCaseObs) ImportCodeOwners(ctx context.Context, content string) (*entity.CodeOwnersImport, error) {
+	const methodName = "import_code_owners"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.ImportCodeOwners(ctx, content)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.ImportCodeOwners")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// GetCodeOwners - метрики
+func (uc *UseCaseObs) GetCodeOwners(ctx context.Context) ([]entity.CodeOwnerRule, error) {
+	const methodName = "get_code_owners"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.GetCodeOwners(ctx)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.GetCodeOwners")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// BackfillTeam - метрики
+func (uc *UseCaseObs) BackfillTeam(ctx context.Context, teamName string) (*entity.BackfillResult, error) {
+	const methodName = "backfill_team"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.BackfillTeam(ctx, teamName)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.BackfillTeam")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// PreviewPullRequest - метрики
+func (uc *UseCaseObs) PreviewPullRequest(ctx context.Context, pr entity.PullRequestCreate) (*entity.AssignmentPreview, error) {
+	const methodName = "preview_pull_request"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.PreviewPullRequest(ctx, pr)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.PreviewPullRequest")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// GetPullRequest - метрики
+func (uc *UseCaseObs) GetPullRequest(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	const methodName = "get_pull_request"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.GetPullRequest(ctx, prID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.GetPullRequest")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// AddReviewer - метрики
+func (uc *UseCaseObs) AddReviewer(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error) {
+	const methodName = "add_reviewer"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.AddReviewer(ctx, change)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.AddReviewer")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// RemoveReviewer - метрики
+func (uc *UseCaseObs) RemoveReviewer(ctx context.Context, change entity.PrReviewerChange) (*entity.PullRequest, error) {
+	const methodName = "remove_reviewer"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.RemoveReviewer(ctx, change)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.RemoveReviewer")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// SubmitReview - метрики
+func (uc *UseCaseObs) SubmitReview(ctx context.Context, review entity.ReviewSubmit) (*entity.PullRequest, error) {
+	const methodName = "submit_review"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.SubmitReview(ctx, review)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.SubmitReview")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// GetMergeOverrides - метрики
+func (uc *UseCaseObs) GetMergeOverrides(ctx context.Context, prID string) ([]entity.MergeOverride, error) {
+	const methodName = "get_merge_overrides"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.GetMergeOverrides(ctx, prID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.GetMergeOverrides")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// MarkReady - метрики
+func (uc *UseCaseObs) MarkReady(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	const methodName = "mark_ready"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.MarkReady(ctx, prID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.MarkReady")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// ClosePr - метрики
+func (uc *UseCaseObs) ClosePr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	const methodName = "close_pr"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.ClosePr(ctx, prID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.ClosePr")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// ReopenPr - метрики
+func (uc *UseCaseObs) ReopenPr(ctx context.Context, prID string) (*entity.PullRequest, error) {
+	const methodName = "reopen_pr"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.ReopenPr(ctx, prID)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.ReopenPr")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// ResolvePullRequestID - метрики
+func (uc *UseCaseObs) ResolvePullRequestID(ctx context.Context, ref entity.PRRef) (string, error) {
+	const methodName = "resolve_pull_request_id"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.ResolvePullRequestID(ctx, ref)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.ResolvePullRequestID")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// SaveRepository - метрики
+func (uc *UseCaseObs) SaveRepository(ctx context.Context, repository entity.Repository) (*entity.Repository, error) {
+	const methodName = "save_repository"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.SaveRepository(ctx, repository)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.SaveRepository")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// GetRepository - метрики
+func (uc *UseCaseObs) GetRepository(ctx context.Context, repositoryName string) (*entity.Repository, error) {
+	const methodName = "get_repository"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.GetRepository(ctx, repositoryName)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.GetRepository")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// AddTeamMember - метрики
+func (uc *UseCaseObs) AddTeamMember(ctx context.Context, add entity.TeamMemberAdd) (*entity.TeamMemberResult, error) {
+	const methodName = "add_team_member"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.AddTeamMember(ctx, add)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.AddTeamMember")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// UpdateTeamMember - метрики
+func (uc *UseCaseObs) UpdateTeamMember(ctx context.Context, update entity.TeamMemberUpdate) (*entity.TeamMemberResult, error) {
+	const methodName = "update_team_member"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.UpdateTeamMember(ctx, update)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.UpdateTeamMember")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// RemoveTeamMember - метрики
+func (uc *UseCaseObs) RemoveTeamMember(ctx context.Context, remove entity.TeamMemberRemove) (*entity.TeamMemberResult, error) {
+	const methodName = "remove_team_member"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.RemoveTeamMember(ctx, remove)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.RemoveTeamMember")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
+
+// MoveUserTeam - метрики
+func (uc *UseCaseObs) MoveUserTeam(ctx context.Context, move entity.UserMove) (*entity.UserMoveResult, error) {
+	const methodName = "move_user_team"
+
+	tracer := otel.Tracer(nameTracer)
+	_, span := tracer.Start(ctx, methodName)
+	defer span.End()
+
+	startTime := time.Now()
+
+	resp, err := uc.UseCase.MoveUserTeam(ctx, move)
+	if err != nil {
+		uc.metrics.HitError(methodName)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to uc.UseCase.MoveUserTeam")
+	} else {
+		uc.metrics.HitSuccess(methodName)
+	}
+
+	uc.metrics.HitDuration(methodName, time.Since(startTime).Seconds())
+
+	return resp, err
+}
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team ADD COLUMN IF NOT EXISTS assignment_strategy TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team DROP COLUMN IF EXISTS assignment_strategy;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team ADD COLUMN IF NOT EXISTS reviewers_count INT NOT NULL DEFAULT 2 CHECK (reviewers_count > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team DROP COLUMN IF EXISTS reviewers_count;
+-- +goose StatementEnd
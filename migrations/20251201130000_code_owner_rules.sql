@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    line INT PRIMARY KEY,
+    pattern TEXT NOT NULL,
+    users TEXT[] NOT NULL DEFAULT '{}',
+    teams TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE IF NOT EXISTS pr_files (
+    pull_request_id TEXT NOT NULL REFERENCES pr(pull_request_id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, path)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pr_files;
+DROP TABLE IF EXISTS code_owner_rules;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr ADD COLUMN IF NOT EXISTS reviewers_count INT CHECK (reviewers_count > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr DROP COLUMN IF EXISTS reviewers_count;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS assignment_reason JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS assignment_reason;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team
+    ADD COLUMN IF NOT EXISTS min_approvals INT NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    ADD COLUMN IF NOT EXISTS block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS require_senior_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS require_code_owner_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS merge_overrides (
+    override_id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pr(pull_request_id) ON DELETE CASCADE,
+    overridden_by TEXT NOT NULL REFERENCES users(user_id),
+    reason TEXT NOT NULL,
+    unmet_conditions JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS merge_overrides;
+
+ALTER TABLE team
+    DROP COLUMN IF EXISTS min_approvals,
+    DROP COLUMN IF EXISTS block_on_changes_requested,
+    DROP COLUMN IF EXISTS require_senior_approval,
+    DROP COLUMN IF EXISTS require_code_owner_approval;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr DROP CONSTRAINT IF EXISTS pr_status_check;
+ALTER TABLE pr ADD CONSTRAINT pr_status_check CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE pr SET status = 'OPEN' WHERE status IN ('DRAFT', 'CLOSED');
+ALTER TABLE pr DROP CONSTRAINT IF EXISTS pr_status_check;
+ALTER TABLE pr ADD CONSTRAINT pr_status_check CHECK (status IN ('OPEN', 'MERGED'));
+-- +goose StatementEnd
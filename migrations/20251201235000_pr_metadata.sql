@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pr
+    ADD COLUMN IF NOT EXISTS repository TEXT,
+    ADD COLUMN IF NOT EXISTS source_branch TEXT,
+    ADD COLUMN IF NOT EXISTS target_branch TEXT,
+    ADD COLUMN IF NOT EXISTS url TEXT,
+    ADD COLUMN IF NOT EXISTS additions INT CHECK (additions >= 0),
+    ADD COLUMN IF NOT EXISTS deletions INT CHECK (deletions >= 0),
+    ADD COLUMN IF NOT EXISTS changed_files_count INT CHECK (changed_files_count >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr
+    DROP COLUMN IF EXISTS repository,
+    DROP COLUMN IF EXISTS source_branch,
+    DROP COLUMN IF EXISTS target_branch,
+    DROP COLUMN IF EXISTS url,
+    DROP COLUMN IF EXISTS additions,
+    DROP COLUMN IF EXISTS deletions,
+    DROP COLUMN IF EXISTS changed_files_count;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS team_size_tiers (
+    team_name TEXT NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    min_lines INT NOT NULL DEFAULT 0 CHECK (min_lines >= 0),
+    min_files INT NOT NULL DEFAULT 0 CHECK (min_files >= 0),
+    reviewers_count INT NOT NULL CHECK (reviewers_count > 0),
+    position INT NOT NULL,
+    PRIMARY KEY (team_name, name)
+);
+
+ALTER TABLE pr ADD COLUMN IF NOT EXISTS size_tier TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr DROP COLUMN IF EXISTS size_tier;
+
+DROP TABLE IF EXISTS team_size_tiers;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS repositories (
+    repository_name TEXT PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS repository_teams (
+    repository_name TEXT NOT NULL REFERENCES repositories(repository_name) ON DELETE CASCADE,
+    team_name TEXT NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    PRIMARY KEY (repository_name, team_name)
+);
+
+INSERT INTO repositories (repository_name)
+SELECT DISTINCT repository FROM pr WHERE repository IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE pr
+    ADD COLUMN IF NOT EXISTS number INT CHECK (number > 0),
+    ADD CONSTRAINT pr_repository_fkey FOREIGN KEY (repository) REFERENCES repositories(repository_name),
+    ADD CONSTRAINT pr_number_repository_check CHECK (number IS NULL OR repository IS NOT NULL),
+    ADD CONSTRAINT pr_repository_number_key UNIQUE (repository, number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pr
+    DROP CONSTRAINT IF EXISTS pr_repository_number_key,
+    DROP CONSTRAINT IF EXISTS pr_number_repository_check,
+    DROP CONSTRAINT IF EXISTS pr_repository_fkey,
+    DROP COLUMN IF EXISTS number;
+
+DROP TABLE IF EXISTS repository_teams;
+
+DROP TABLE IF EXISTS repositories;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS team_members (
+    team_name TEXT NOT NULL REFERENCES team(team_name) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (team_name, user_id)
+);
+
+-- у пользователя не больше одной основной команды
+CREATE UNIQUE INDEX IF NOT EXISTS team_members_primary_idx ON team_members (user_id) WHERE is_primary;
+
+CREATE INDEX IF NOT EXISTS team_members_user_idx ON team_members (user_id);
+
+INSERT INTO team_members (team_name, user_id, is_primary)
+SELECT team_name, user_id, TRUE FROM users WHERE team_name IS NOT NULL;
+
+-- команда, для которой создан pr: из нее подбираются ревьюверы и берутся настройки
+ALTER TABLE pr ADD COLUMN IF NOT EXISTS team_name TEXT REFERENCES team(team_name);
+
+UPDATE pr p SET team_name = m.team_name
+FROM team_members m
+WHERE m.user_id = p.author_id AND m.is_primary;
+
+ALTER TABLE users DROP COLUMN IF EXISTS team_name;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS team_name TEXT REFERENCES team(team_name) ON DELETE CASCADE;
+
+-- до team_members у пользователя была одна команда - возвращаем основную
+UPDATE users u SET team_name = m.team_name
+FROM team_members m
+WHERE m.user_id = u.user_id AND m.is_primary;
+
+-- убранный из всех команд пользователь возвращается в команду последнего pr, где он автор или ревьювер
+UPDATE users u SET team_name = (
+    SELECT p.team_name FROM pr p
+    WHERE p.team_name IS NOT NULL
+        AND (p.author_id = u.user_id OR EXISTS (
+            SELECT 1 FROM pr_reviewers r WHERE r.pull_request_id = p.pull_request_id AND r.user_id = u.user_id))
+    ORDER BY p.created_at DESC
+    LIMIT 1)
+WHERE u.team_name IS NULL;
+
+-- пользователь без команды и без pr в старой схеме существовать не может
+DELETE FROM users u
+WHERE u.team_name IS NULL
+    AND NOT EXISTS (SELECT 1 FROM pr WHERE author_id = u.user_id)
+    AND NOT EXISTS (SELECT 1 FROM pr_reviewers WHERE user_id = u.user_id)
+    AND NOT EXISTS (SELECT 1 FROM merge_overrides WHERE overridden_by = u.user_id);
+
+ALTER TABLE users ALTER COLUMN team_name SET NOT NULL;
+
+ALTER TABLE pr DROP COLUMN IF EXISTS team_name;
+
+DROP TABLE IF EXISTS team_members;
+-- +goose StatementEnd